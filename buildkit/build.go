@@ -54,6 +54,8 @@ type BuildWithBuildkitClientOptions struct {
 	ExportCache  string
 	CacheKey     string
 	GitHubToken  string
+
+	InlineReleaseCommand bool
 }
 
 func BuildWithBuildkitClient(appDir string, plan *plan.BuildPlan, opts BuildWithBuildkitClientOptions) error {
@@ -95,6 +97,8 @@ func BuildWithBuildkitClient(appDir string, plan *plan.BuildPlan, opts BuildWith
 		SecretsHash:   opts.SecretsHash,
 		CacheKey:      opts.CacheKey,
 		GitHubToken:   opts.GitHubToken,
+
+		InlineReleaseCommand: opts.InlineReleaseCommand,
 	})
 	if err != nil {
 		return fmt.Errorf("error converting plan to LLB: %w", err)
@@ -41,7 +41,7 @@ func NewBuildGraph(plan *plan.BuildPlan, localState *llb.State, cacheStore *Buil
 		st := llb.Scratch().File(llb.Mkfile("/secrets-hash", 0644, []byte(secretsHash)), llb.WithCustomName("[railpack] secrets hash"))
 		secretsFile = &st
 	}
-	usedSecretsBase := llb.Image("alpine:latest", llb.WithCustomName("[railpack] loading secrets"))
+	usedSecretsBase := llb.Image(plan.GetSecretsImage(), llb.WithCustomName("[railpack] loading secrets"))
 
 	g := &BuildGraph{
 		graph:      graph.NewGraph(),
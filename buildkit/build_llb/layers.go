@@ -16,7 +16,9 @@ import (
 func (g *BuildGraph) GetStateForLayer(layer plan.Layer) llb.State {
 	var state llb.State
 
-	if layer.Image != "" {
+	if layer.Image == plan.ScratchImage {
+		state = llb.Scratch()
+	} else if layer.Image != "" {
 		state = llb.Image(layer.Image, llb.Platform(*g.Platform))
 	} else if layer.Local {
 		state = *g.LocalState
@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/google/shlex"
 	"github.com/moby/buildkit/client/llb"
 	"github.com/moby/buildkit/util/system"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
@@ -27,10 +28,16 @@ type ConvertPlanOptions struct {
 
 	// Token used to make authenticated API requests to GitHub to increase rate limits
 	GitHubToken string
+
+	// Run the release command before the start command, for platforms that do not run it once per deployment
+	InlineReleaseCommand bool
 }
 
 const (
 	WorkingDir = "/app"
+
+	// Image label with the release command of the deploy, for platforms that run it once per deployment
+	ReleaseCommandLabel = "railpack.release-command"
 )
 
 func ConvertPlanToLLB(plan *p.BuildPlan, opts ConvertPlanOptions) (*llb.State, *Image, error) {
@@ -62,6 +69,29 @@ func ConvertPlanToLLB(plan *p.BuildPlan, opts ConvertPlanOptions) (*llb.State, *
 		startCommand = "/bin/bash"
 	}
 
+	labels := map[string]string{}
+	if releaseCommand := plan.Deploy.ReleaseCmd; releaseCommand != "" {
+		labels[ReleaseCommandLabel] = releaseCommand
+		if opts.InlineReleaseCommand {
+			startCommand = fmt.Sprintf("%s && %s", releaseCommand, startCommand)
+		}
+	}
+
+	entrypoint := []string{"/bin/bash", "-c"}
+	cmd := []string{startCommand}
+	if plan.Deploy.NoShell {
+		// The base image does not have a shell, so the start command is run directly
+		if p.CommandNeedsShell(startCommand) {
+			return nil, nil, fmt.Errorf("start command `%s` uses shell syntax, but the deploy image does not have a shell. Run the binary directly or set RAILPACK_STATIC_BASE=runtime", startCommand)
+		}
+
+		entrypoint = nil
+		cmd, err = shlex.Split(startCommand)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing start command: %w", err)
+		}
+	}
+
 	image := Image{
 		Image: specs.Image{
 			Platform: specs.Platform{
@@ -76,8 +106,9 @@ func ConvertPlanToLLB(plan *p.BuildPlan, opts ConvertPlanOptions) (*llb.State, *
 		Config: specs.ImageConfig{
 			Env:        imageEnv,
 			WorkingDir: WorkingDir,
-			Entrypoint: []string{"/bin/bash", "-c"},
-			Cmd:        []string{startCommand},
+			Entrypoint: entrypoint,
+			Cmd:        cmd,
+			Labels:     labels,
 		},
 	}
 
@@ -0,0 +1,46 @@
+package buildkit
+
+import (
+	"testing"
+
+	"github.com/railwayapp/railpack/core/plan"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertPlanToLLB_ReleaseCommand(t *testing.T) {
+	buildPlan := plan.NewBuildPlan()
+	buildPlan.Deploy = plan.Deploy{
+		Base:       plan.NewImageLayer(plan.RailpackRuntimeImage),
+		StartCmd:   "gunicorn mysite.wsgi:application",
+		ReleaseCmd: "python manage.py migrate",
+	}
+
+	// The platform runs the release command from the label once per deployment
+	_, image, err := ConvertPlanToLLB(buildPlan, ConvertPlanOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"gunicorn mysite.wsgi:application"}, image.Config.Cmd)
+	require.Equal(t, "python manage.py migrate", image.Config.Labels[ReleaseCommandLabel])
+
+	_, image, err = ConvertPlanToLLB(buildPlan, ConvertPlanOptions{InlineReleaseCommand: true})
+	require.NoError(t, err)
+	require.Equal(t, []string{"python manage.py migrate && gunicorn mysite.wsgi:application"}, image.Config.Cmd)
+	require.Equal(t, "python manage.py migrate", image.Config.Labels[ReleaseCommandLabel])
+}
+
+func TestConvertPlanToLLB_NoShell(t *testing.T) {
+	buildPlan := plan.NewBuildPlan()
+	buildPlan.Deploy = plan.Deploy{
+		Base:     plan.NewImageLayer(plan.DistrolessStaticImage),
+		StartCmd: "./bin/server --port 8080",
+		NoShell:  true,
+	}
+
+	_, image, err := ConvertPlanToLLB(buildPlan, ConvertPlanOptions{})
+	require.NoError(t, err)
+	require.Nil(t, image.Config.Entrypoint)
+	require.Equal(t, []string{"./bin/server", "--port", "8080"}, image.Config.Cmd)
+
+	buildPlan.Deploy.StartCmd = "./bin/server --port $PORT"
+	_, _, err = ConvertPlanToLLB(buildPlan, ConvertPlanOptions{})
+	require.ErrorContains(t, err, "uses shell syntax")
+}
@@ -30,6 +30,8 @@ const (
 	cacheKey = "cache-key"
 
 	githubToken = "github-token"
+
+	inlineReleaseCommand = "inline-release-command"
 )
 
 func StartFrontend() {
@@ -49,6 +51,7 @@ func Build(ctx context.Context, c client.Client) (*client.Result, error) {
 	cacheKey := buildArgs[cacheKey]
 	secretsHash := buildArgs[secretsHash]
 	githubToken := buildArgs[githubToken]
+	inlineRelease := buildArgs[inlineReleaseCommand] == "true"
 
 	// TODO: Support building for multiple platforms
 	buildPlatform, err := validatePlatform(opts)
@@ -72,6 +75,8 @@ func Build(ctx context.Context, c client.Client) (*client.Result, error) {
 		CacheKey:      cacheKey,
 		SessionID:     c.BuildOpts().SessionID,
 		GitHubToken:   githubToken,
+
+		InlineReleaseCommand: inlineRelease,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error converting plan to LLB: %w", err)
@@ -117,6 +122,10 @@ func readRailpackPlan(ctx context.Context, c client.Client) (*plan.BuildPlan, er
 		return nil, errors.Wrap(err, "failed to parse railpack plan")
 	}
 
+	if err := plan.ValidateStepGraph(); err != nil {
+		return nil, errors.Wrap(err, "invalid railpack plan")
+	}
+
 	return plan, nil
 }
 
@@ -2,6 +2,7 @@ package graph
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Node represents a node in a directed graph
@@ -47,15 +48,19 @@ func (g *Graph) ComputeProcessingOrder() ([]Node, error) {
 	visited := make(map[string]bool)
 	temp := make(map[string]bool)
 
+	// The nodes currently being visited, used to report the full path of a cycle
+	path := []string{}
+
 	var visit func(node Node) error
 	visit = func(node Node) error {
 		if temp[node.GetName()] {
-			return fmt.Errorf("cycle detected: %s", node.GetName())
+			return fmt.Errorf("cycle detected: %s", formatCycle(path, node.GetName()))
 		}
 		if visited[node.GetName()] {
 			return nil
 		}
 		temp[node.GetName()] = true
+		path = append(path, node.GetName())
 
 		// Visit parents first to ensure they are processed before this node
 		for _, parent := range node.GetParents() {
@@ -64,6 +69,7 @@ func (g *Graph) ComputeProcessingOrder() ([]Node, error) {
 			}
 		}
 
+		path = path[:len(path)-1]
 		delete(temp, node.GetName())
 		visited[node.GetName()] = true
 		order = append(order, node)
@@ -91,6 +97,16 @@ func (g *Graph) ComputeProcessingOrder() ([]Node, error) {
 	return order, nil
 }
 
+// formatCycle returns the part of the path that starts at the repeated node (e.g. "A -> B -> C -> A")
+func formatCycle(path []string, repeated string) string {
+	for i, name := range path {
+		if name == repeated {
+			return strings.Join(append(append([]string{}, path[i:]...), repeated), " -> ")
+		}
+	}
+	return repeated
+}
+
 // ComputeTransitiveDependencies removes redundant edges from the graph
 func (g *Graph) ComputeTransitiveDependencies() {
 	for _, node := range g.nodes {
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -127,6 +128,14 @@ func TestGraphCycleDetection(t *testing.T) {
 	if err == nil {
 		t.Error("Expected cycle detection error, got nil")
 	}
+
+	// The error includes the full path of the cycle, starting from whichever node was visited first
+	if err != nil {
+		cycle := strings.Split(strings.TrimPrefix(err.Error(), "cycle detected: "), " -> ")
+		if len(cycle) != 4 || cycle[0] != cycle[3] {
+			t.Errorf("Expected cycle path in error, got %s", err.Error())
+		}
+	}
 }
 
 func TestTransitiveDependencies(t *testing.T) {
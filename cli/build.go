@@ -47,6 +47,11 @@ var BuildCommand = &cli.Command{
 			Name:  "cache-key",
 			Usage: "Unique id to prefix to cache keys",
 		},
+		&cli.BoolFlag{
+			Name:  "inline-release-command",
+			Usage: "Run the release command before the start command every time the container starts. By default it is only set in the railpack.release-command image label",
+			Value: false,
+		},
 		&cli.BoolFlag{
 			Name:   "dump-llb",
 			Hidden: true,
@@ -101,6 +106,8 @@ var BuildCommand = &cli.Command{
 			Secrets:      env.Variables,
 			Platform:     platform,
 			GitHubToken:  os.Getenv("GITHUB_TOKEN"),
+
+			InlineReleaseCommand: cmd.Bool("inline-release-command"),
 		})
 		if err != nil {
 			return cli.Exit(err, 1)
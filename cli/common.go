@@ -21,6 +21,10 @@ func commonPlanFlags() []cli.Flag {
 			Name:  "dev",
 			Usage: "generate development config (commands/env for local run)",
 		},
+		&cli.BoolFlag{
+			Name:  "debug",
+			Usage: "generate development config that starts the app under a debugger (implies --dev)",
+		},
 		&cli.StringSliceFlag{
 			Name:    "env",
 			Aliases: []string{"e"},
@@ -46,6 +50,10 @@ func commonPlanFlags() []cli.Flag {
 			Name:  "error-missing-start",
 			Usage: "error if no start command is found",
 		},
+		&cli.BoolFlag{
+			Name:  "pin-images",
+			Usage: "pin every image in the plan to its current digest",
+		},
 	}
 }
 
@@ -56,6 +64,11 @@ func GenerateBuildResultForCommand(cmd *cli.Command) (*core.BuildResult, *a.App,
 		return nil, nil, nil, cli.Exit("directory argument is required", 1)
 	}
 
+	return generateBuildResultForDirectory(cmd, directory)
+}
+
+// generateBuildResultForDirectory generates a build result for the given directory using the common plan flags of the command
+func generateBuildResultForDirectory(cmd *cli.Command, directory string) (*core.BuildResult, *a.App, *a.Environment, error) {
 	app, err := a.NewApp(directory)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("error creating app: %w", err)
@@ -86,6 +99,10 @@ func GenerateBuildResultForCommand(cmd *cli.Command) (*core.BuildResult, *a.App,
 		ConfigFilePath:           cmd.String("config-file"),
 		ErrorMissingStartCommand: cmd.Bool("error-missing-start"),
 		Dev:                      cmd.Bool("dev"),
+		Debug:                    cmd.Bool("debug"),
+		// `railpack lock --update` regenerates the lock file from scratch
+		IgnoreLockFile: cmd.Bool("update"),
+		PinImages:      cmd.Bool("pin-images"),
 	}
 
 	buildResult := core.GenerateBuildPlan(app, env, generateOptions)
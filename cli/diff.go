@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/railwayapp/railpack/core"
+	"github.com/railwayapp/railpack/core/plan"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	diffExitCodeChanges = 1
+	diffExitCodeError   = 2
+)
+
+var DiffCommand = &cli.Command{
+	Name:                  "diff",
+	Usage:                 "compare two build plans and exit non-zero if they differ",
+	ArgsUsage:             "OLD [NEW]",
+	Description:           "OLD and NEW can each be a saved plan JSON file, a saved build info JSON file, a directory, or a git revision of the current repository. If NEW is omitted, the current directory is used.",
+	EnableShellCompletion: true,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "output format. one of: text, json",
+			Value: "text",
+		},
+	}, commonPlanFlags()...),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		oldSource := cmd.Args().Get(0)
+		newSource := cmd.Args().Get(1)
+
+		if oldSource == "" {
+			return cli.Exit("at least one plan, directory, or revision to compare against is required", diffExitCodeError)
+		}
+		if newSource == "" {
+			newSource = "."
+		}
+
+		oldResult, err := loadDiffSource(cmd, oldSource)
+		if err != nil {
+			return cli.Exit(err, diffExitCodeError)
+		}
+
+		newResult, err := loadDiffSource(cmd, newSource)
+		if err != nil {
+			return cli.Exit(err, diffExitCodeError)
+		}
+
+		changes := core.DiffBuildResults(oldResult, newResult)
+
+		if cmd.String("format") == "json" {
+			serialized, err := json.MarshalIndent(changes, "", "  ")
+			if err != nil {
+				return cli.Exit(err, diffExitCodeError)
+			}
+			os.Stdout.Write(serialized)
+			os.Stdout.Write([]byte("\n"))
+		} else {
+			for _, change := range changes {
+				fmt.Println(change.String())
+			}
+		}
+
+		if len(changes) > 0 {
+			log.Debugf("Found %d differences between %s and %s", len(changes), oldSource, newSource)
+			return cli.Exit("", diffExitCodeChanges)
+		}
+
+		return nil
+	},
+}
+
+// loadDiffSource loads a build result from a saved JSON file, a directory, or a git revision
+func loadDiffSource(cmd *cli.Command, source string) (*core.BuildResult, error) {
+	info, err := os.Stat(source)
+	if err == nil && !info.IsDir() {
+		return readBuildResultFile(source)
+	}
+
+	if err == nil && info.IsDir() {
+		return generateDiffBuildResult(cmd, source)
+	}
+
+	dir, cleanup, revErr := checkoutRevision(source)
+	if revErr != nil {
+		return nil, fmt.Errorf("%s is not a file, directory, or git revision: %w", source, revErr)
+	}
+	defer cleanup()
+
+	return generateDiffBuildResult(cmd, dir)
+}
+
+func generateDiffBuildResult(cmd *cli.Command, directory string) (*core.BuildResult, error) {
+	buildResult, _, _, err := generateBuildResultForDirectory(cmd, directory)
+	if err != nil {
+		return nil, err
+	}
+
+	if !buildResult.Success {
+		core.PrettyPrintBuildResult(buildResult, core.PrintOptions{Version: Version})
+		return nil, fmt.Errorf("failed to generate build plan for %s", directory)
+	}
+
+	return buildResult, nil
+}
+
+// readBuildResultFile reads either the output of `railpack info --format json` or `railpack plan`
+func readBuildResultFile(path string) (*core.BuildResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("error reading %s as JSON: %w", path, err)
+	}
+
+	buildResult := &core.BuildResult{}
+	if _, ok := raw["plan"]; ok {
+		if err := json.Unmarshal(data, buildResult); err != nil {
+			return nil, fmt.Errorf("error reading %s as build info: %w", path, err)
+		}
+		return buildResult, nil
+	}
+
+	buildPlan := &plan.BuildPlan{}
+	if err := json.Unmarshal(data, buildPlan); err != nil {
+		return nil, fmt.Errorf("error reading %s as build plan: %w", path, err)
+	}
+	buildResult.Plan = buildPlan
+
+	return buildResult, nil
+}
+
+// checkoutRevision extracts the current directory at the given git revision into a temporary directory
+func checkoutRevision(revision string) (string, func(), error) {
+	tmpDir, err := os.MkdirTemp("", "railpack-diff-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	// When run from a subdirectory, git archive only includes that subdirectory
+	archive := exec.Command("git", "archive", "--format=tar", revision)
+	var stderr strings.Builder
+	archive.Stderr = &stderr
+
+	stdout, err := archive.StdoutPipe()
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	if err := archive.Start(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	extractErr := extractTar(stdout, tmpDir)
+	waitErr := archive.Wait()
+
+	if waitErr != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("git archive failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	if extractErr != nil {
+		cleanup()
+		return "", nil, extractErr
+	}
+
+	return tmpDir, cleanup, nil
+}
+
+func extractTar(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dest, header.Name)
+		if !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid file path in archive: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			f.Close()
+		case tar.TypeSymlink:
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
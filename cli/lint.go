@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/railwayapp/railpack/core"
+	"github.com/railwayapp/railpack/core/lint"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	lintExitCodeFindings = 1
+	lintExitCodeError    = 2
+)
+
+var LintCommand = &cli.Command{
+	Name:                  "lint",
+	Usage:                 "check a build plan for common problems and exit non-zero if any are found",
+	ArgsUsage:             "DIRECTORY|PLAN",
+	Description:           "The argument can be an app directory or a saved plan or build info JSON file.",
+	EnableShellCompletion: true,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "output format. one of: text, json, sarif",
+			Value: "text",
+		},
+	}, commonPlanFlags()...),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		source := cmd.Args().First()
+		if source == "" {
+			return cli.Exit("directory or plan argument is required", lintExitCodeError)
+		}
+
+		findings, artifact, err := lintSource(cmd, source)
+		if err != nil {
+			return cli.Exit(err, lintExitCodeError)
+		}
+
+		switch cmd.String("format") {
+		case "json":
+			if err := writeJSON(findings); err != nil {
+				return cli.Exit(err, lintExitCodeError)
+			}
+		case "sarif":
+			if err := writeJSON(lint.ToSarif(findings, Version, artifact)); err != nil {
+				return cli.Exit(err, lintExitCodeError)
+			}
+		default:
+			for _, finding := range findings {
+				fmt.Println(finding.String())
+			}
+		}
+
+		if len(findings) > 0 {
+			log.Debugf("Found %d lint findings in %s", len(findings), source)
+			return cli.Exit("", lintExitCodeFindings)
+		}
+
+		return nil
+	},
+}
+
+// lintSource lints a saved plan file or the plan generated for a directory.
+// It also returns the file that findings should be attributed to, if any.
+func lintSource(cmd *cli.Command, source string) ([]lint.Finding, string, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !info.IsDir() {
+		buildResult, err := readBuildResultFile(source)
+		if err != nil {
+			return nil, "", err
+		}
+		if buildResult.Plan == nil {
+			return nil, "", fmt.Errorf("%s does not contain a build plan", source)
+		}
+
+		// A saved plan has not necessarily been normalized, so unreachable steps are still in it
+		findings := lint.Lint(&lint.Context{
+			Plan:             buildResult.Plan,
+			UnreachableSteps: buildResult.Plan.UnreachableSteps(),
+		})
+		return findings, source, nil
+	}
+
+	buildResult, app, _, err := generateBuildResultForDirectory(cmd, source)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !buildResult.Success {
+		core.PrettyPrintBuildResult(buildResult, core.PrintOptions{Version: Version})
+		return nil, "", fmt.Errorf("failed to generate build plan for %s", source)
+	}
+
+	artifact := ""
+	configFile := cmd.String("config-file")
+	if configFile == "" {
+		configFile = "railpack.json"
+	}
+	if app.HasFile(configFile) {
+		artifact = configFile
+	}
+
+	return buildResult.Lint, artifact, nil
+}
+
+func writeJSON(v any) error {
+	serialized, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	os.Stdout.Write(serialized)
+	os.Stdout.Write([]byte("\n"))
+	return nil
+}
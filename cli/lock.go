@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"context"
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/railwayapp/railpack/core"
+	"github.com/urfave/cli/v3"
+)
+
+var LockCommand = &cli.Command{
+	Name:                  "lock",
+	Usage:                 "write a railpack.lock file with the resolved package versions and image digests",
+	ArgsUsage:             "DIRECTORY",
+	EnableShellCompletion: true,
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:  "update",
+			Usage: "ignore the existing lock file and resolve the latest versions",
+		},
+	}, commonPlanFlags()...),
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		buildResult, app, _, err := GenerateBuildResultForCommand(cmd)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+
+		if !buildResult.Success {
+			core.PrettyPrintBuildResult(buildResult, core.PrintOptions{Version: Version})
+			os.Exit(1)
+			return nil
+		}
+
+		lockFile, err := core.NewLockFile(buildResult)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+
+		if err := lockFile.Write(app.Source); err != nil {
+			return cli.Exit(err, 1)
+		}
+
+		log.Infof("Lock file written to %s/%s", app.Source, core.LockFileName)
+
+		return nil
+	},
+}
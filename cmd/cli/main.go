@@ -40,6 +40,9 @@ func main() {
 		cli.PrepareCommand,
 		cli.InfoCommand,
 		cli.PlanCommand,
+		cli.DiffCommand,
+		cli.LockCommand,
+		cli.LintCommand,
 		cli.SchemaCommand,
 		cli.FrontendCommand,
 	}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+)
+
+var dotenvKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// ParseDotenv parses the contents of a .env file.
+// Lines are in the format KEY=value and may start with `export`. Values can be single quoted (taken literally),
+// double quoted (with \n, \t, \" and \\ escapes), or unquoted (with trailing comments removed).
+func ParseDotenv(contents string) (map[string]string, error) {
+	variables := map[string]string{}
+
+	lines := strings.Split(strings.ReplaceAll(contents, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !dotenvKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("invalid line %d: expected KEY=value", i+1)
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'"):
+			quote := value[:1]
+			value = value[1:]
+
+			// Quoted values can span multiple lines
+			for closingQuoteIndex(value, quote) == -1 && i+1 < len(lines) {
+				i++
+				value += "\n" + lines[i]
+			}
+
+			end := closingQuoteIndex(value, quote)
+			if end == -1 {
+				return nil, fmt.Errorf("invalid line %d: unterminated quoted value for %s", i+1, key)
+			}
+			value = value[:end]
+
+			if quote == `"` {
+				value = strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(value)
+			}
+		default:
+			if index := strings.Index(value, " #"); index != -1 {
+				value = strings.TrimSpace(value[:index])
+			}
+		}
+
+		variables[key] = value
+	}
+
+	return variables, nil
+}
+
+// closingQuoteIndex returns the index of the quote that ends the value, skipping escaped double quotes
+func closingQuoteIndex(value, quote string) int {
+	for i := 0; i < len(value); i++ {
+		if quote == `"` && value[i] == '\\' {
+			i++
+			continue
+		}
+		if value[i] == quote[0] {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReadDotenvFiles reads the .env files that exist in the app. Later files take precedence over earlier ones.
+// The names of the files that were read are also returned.
+func (a *App) ReadDotenvFiles(names []string) (map[string]string, []string, error) {
+	variables := map[string]string{}
+	read := []string{}
+
+	for _, name := range names {
+		if !a.HasFile(name) {
+			continue
+		}
+
+		contents, err := a.ReadFile(name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fileVariables, err := ParseDotenv(contents)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading %s: %w", name, err)
+		}
+
+		maps.Copy(variables, fileVariables)
+		read = append(read, name)
+	}
+
+	return variables, read, nil
+}
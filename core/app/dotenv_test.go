@@ -0,0 +1,58 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDotenv(t *testing.T) {
+	contents := `# comment
+PLAIN=value
+export EXPORTED=yes
+SPACED = spaced value # trailing comment
+HASH=abc#def
+SINGLE='literal $HOME \n'
+DOUBLE="line1\nline2 \"quoted\""
+MULTI="first
+second"
+EMPTY=
+`
+
+	variables, err := ParseDotenv(contents)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"PLAIN":    "value",
+		"EXPORTED": "yes",
+		"SPACED":   "spaced value",
+		"HASH":     "abc#def",
+		"SINGLE":   `literal $HOME \n`,
+		"DOUBLE":   "line1\nline2 \"quoted\"",
+		"MULTI":    "first\nsecond",
+		"EMPTY":    "",
+	}, variables)
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	_, err := ParseDotenv("NOT A VARIABLE")
+	require.EqualError(t, err, "invalid line 1: expected KEY=value")
+
+	_, err = ParseDotenv("A=1\nB=\"unterminated")
+	require.EqualError(t, err, "invalid line 2: unterminated quoted value for B")
+}
+
+func TestReadDotenvFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("A=env\nB=env\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env.local"), []byte("B=local\n"), 0644))
+
+	a, err := NewApp(dir)
+	require.NoError(t, err)
+
+	variables, files, err := a.ReadDotenvFiles([]string{".env", ".env.development", ".env.local"})
+	require.NoError(t, err)
+	require.Equal(t, []string{".env", ".env.local"}, files)
+	require.Equal(t, map[string]string{"A": "env", "B": "local"}, variables)
+}
@@ -18,6 +18,7 @@ type DeployConfig struct {
 	Inputs       []plan.Layer      `json:"inputs,omitempty" jsonschema:"description=The inputs for the deploy step"`
 	StartCmd     string            `json:"startCommand,omitempty" jsonschema:"description=The command to run in the container"`
 	StartCmdHost string            `json:"startCommandHost,omitempty" jsonschema:"description=Optional full host-binding command to run on the host (e.g. npm start -- --host)"`
+	ReleaseCmd   string            `json:"releaseCommand,omitempty" jsonschema:"description=The command to run once before a new deployment is started (e.g. database migrations)"`
 	Variables    map[string]string `json:"variables,omitempty" jsonschema:"description=The variables available to this step. The key is the name of the variable that is referenced in a variable command"`
 	Paths        []string          `json:"paths,omitempty" jsonschema:"description=The paths to prepend to the $PATH environment variable"`
 }
@@ -27,14 +28,52 @@ type StepConfig struct {
 	DeployOutputs []plan.Filter `json:"deployOutputs,omitempty" jsonschema:"description=Parts of this step that should be included in the final image. If empty, the /app directory will be used."`
 }
 
+type NodeConfig struct {
+	Workspace string `json:"workspace,omitempty" jsonschema:"description=Path or name of the workspace package to build and deploy (e.g. apps/api)"`
+}
+
+type PythonConfig struct {
+	Package string `json:"package,omitempty" jsonschema:"description=Path or name of the uv or Poetry workspace member to install and deploy (e.g. packages/api)"`
+}
+
+type DotenvConfig struct {
+	Production bool     `json:"production,omitempty" jsonschema:"description=Load .env.production as build-time variables in production builds"`
+	NonSecret  []string `json:"nonSecret,omitempty" jsonschema:"description=Names of .env variables that are not secret and can be set directly on build steps. A trailing * matches a prefix (e.g. NEXT_PUBLIC_*)"`
+}
+
+type StaticConfig struct {
+	Headers      map[string]map[string]string `json:"headers,omitempty" jsonschema:"description=Map of path patterns (e.g. /assets/*) to the headers added to responses"`
+	CacheControl map[string]string            `json:"cacheControl,omitempty" jsonschema:"description=Map of path patterns (e.g. /assets/*) to the Cache-Control header of responses"`
+	Redirects    []StaticRedirect             `json:"redirects,omitempty" jsonschema:"description=Redirects and rewrites. Rules from a _redirects file are added after these"`
+	Proxy        map[string]string            `json:"proxy,omitempty" jsonschema:"description=Map of path patterns (e.g. /api/*) to the upstream URL that requests are proxied to"`
+	Precompress  bool                         `json:"precompress,omitempty" jsonschema:"description=Compress static files with gzip and brotli during the build so they are not compressed on every request"`
+}
+
+type StaticRedirect struct {
+	From   string `json:"from" jsonschema:"description=Path to redirect from. A trailing * matches any path with the prefix"`
+	To     string `json:"to" jsonschema:"description=Path or URL to redirect to. :splat is replaced with the path matched by *"`
+	Status int    `json:"status,omitempty" jsonschema:"description=Status code of the redirect (defaults to 301). 200 rewrites the request instead, or proxies it if to is a URL"`
+	Force  bool   `json:"force,omitempty" jsonschema:"description=Apply the rule even if a file exists at the path"`
+}
+
+type SystemPackages struct {
+	Build   []string `json:"build,omitempty" jsonschema:"description=Apt packages installed to build the dependency"`
+	Runtime []string `json:"runtime,omitempty" jsonschema:"description=Apt packages installed in the final image to run the dependency"`
+}
+
 type Config struct {
-	Provider         *string                `json:"provider" jsonschema:"description=The provider to use"`
-	BuildAptPackages []string               `json:"buildAptPackages,omitempty" jsonschema:"description=List of apt packages to install during the build step"`
-	Steps            map[string]*StepConfig `json:"steps,omitempty" jsonschema:"description=Map of step names to step definitions"`
-	Deploy           *DeployConfig          `json:"deploy,omitempty" jsonschema:"description=Deploy configuration"`
-	Packages         map[string]string      `json:"packages,omitempty" jsonschema:"description=Map of package name to package version"`
-	Caches           map[string]*plan.Cache `json:"caches,omitempty" jsonschema:"description=Map of cache name to cache definitions. The cache key can be referenced in an exec command"`
-	Secrets          []string               `json:"secrets,omitempty" jsonschema:"description=Secrets that should be made available to commands that have useSecrets set to true"`
+	Provider         *string                               `json:"provider" jsonschema:"description=The provider to use"`
+	BuildAptPackages []string                              `json:"buildAptPackages,omitempty" jsonschema:"description=List of apt packages to install during the build step"`
+	Steps            map[string]*StepConfig                `json:"steps,omitempty" jsonschema:"description=Map of step names to step definitions"`
+	Deploy           *DeployConfig                         `json:"deploy,omitempty" jsonschema:"description=Deploy configuration"`
+	Packages         map[string]string                     `json:"packages,omitempty" jsonschema:"description=Map of package name to package version"`
+	Caches           map[string]*plan.Cache                `json:"caches,omitempty" jsonschema:"description=Map of cache name to cache definitions. The cache key can be referenced in an exec command"`
+	Secrets          []string                              `json:"secrets,omitempty" jsonschema:"description=Secrets that should be made available to commands that have useSecrets set to true"`
+	Node             *NodeConfig                           `json:"node,omitempty" jsonschema:"description=Node provider configuration"`
+	Python           *PythonConfig                         `json:"python,omitempty" jsonschema:"description=Python provider configuration"`
+	Dotenv           *DotenvConfig                         `json:"dotenv,omitempty" jsonschema:"description=Configuration for loading variables from .env files"`
+	Static           *StaticConfig                         `json:"static,omitempty" jsonschema:"description=Configuration for static site deploys served by Caddy"`
+	SystemPackages   map[string]map[string]*SystemPackages `json:"systemPackages,omitempty" jsonschema:"description=Map of ecosystems (node, python, ruby) to the apt packages that each dependency needs. These replace the packages Railpack installs for the dependency"`
 }
 
 func EmptyConfig() *Config {
@@ -13,6 +13,7 @@ import (
 	"github.com/railwayapp/railpack/core/app"
 	c "github.com/railwayapp/railpack/core/config"
 	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/lint"
 	"github.com/railwayapp/railpack/core/logger"
 	"github.com/railwayapp/railpack/core/plan"
 	"github.com/railwayapp/railpack/core/providers"
@@ -33,6 +34,15 @@ type GenerateBuildPlanOptions struct {
 	ConfigFilePath           string
 	ErrorMissingStartCommand bool
 	Dev                      bool
+
+	// If true, the development plan starts the app under a debugger. This implies Dev.
+	Debug bool
+
+	// If true, the railpack.lock file in the app directory is not used
+	IgnoreLockFile bool
+
+	// If true, every image in the plan is resolved and pinned to its current digest
+	PinImages bool
 }
 
 type BuildResult struct {
@@ -42,6 +52,7 @@ type BuildResult struct {
 	Metadata          map[string]string                    `json:"metadata,omitempty"`
 	DetectedProviders []string                             `json:"detectedProviders,omitempty"`
 	Logs              []logger.Msg                         `json:"logs,omitempty"`
+	Lint              []lint.Finding                       `json:"lint,omitempty"`
 	Success           bool                                 `json:"success,omitempty"`
 }
 
@@ -75,6 +86,14 @@ func GenerateBuildPlan(app *app.App, env *app.Environment, options *GenerateBuil
 		return &BuildResult{Success: false, Logs: logger.Logs}
 	}
 
+	dev := options.Dev || options.Debug
+
+	buildVariables, err := applyDotenv(app, env, config, dev, logger)
+	if err != nil {
+		logger.LogError("%s", err.Error())
+		return &BuildResult{Success: false, Logs: logger.Logs}
+	}
+
 	ctx, err := generate.NewGenerateContext(app, env, config, logger)
 	if err != nil {
 		logger.LogError("%s", err.Error())
@@ -82,7 +101,9 @@ func GenerateBuildPlan(app *app.App, env *app.Environment, options *GenerateBuil
 	}
 
 	// Propagate dev mode to the generation context so providers can branch on it
-	ctx.Dev = options.Dev
+	ctx.Dev = dev
+	ctx.Debug = options.Debug
+	ctx.BuildVariables = buildVariables
 
 	// Set the previous versions
 	if options.PreviousVersions != nil {
@@ -91,6 +112,21 @@ func GenerateBuildPlan(app *app.App, env *app.Environment, options *GenerateBuil
 		}
 	}
 
+	// Use the package versions and image digests from the lock file if there is one
+	var lockFile *LockFile
+	if !options.IgnoreLockFile {
+		lockFile, err = ReadLockFile(app)
+		if err != nil {
+			logger.LogError("%s", err.Error())
+			return &BuildResult{Success: false, Logs: logger.Logs}
+		}
+
+		if lockFile != nil {
+			logger.LogInfo("Using lock file `%s`", LockFileName)
+			applyLockFile(lockFile, ctx.Resolver)
+		}
+	}
+
 	// Figure out what providers to use
 	providerToUse, detectedProviderName := getProviders(ctx, config)
 	ctx.Metadata.Set("providers", detectedProviderName)
@@ -119,6 +155,17 @@ func GenerateBuildPlan(app *app.App, env *app.Environment, options *GenerateBuil
 		return &BuildResult{Success: false, Logs: logger.Logs}
 	}
 
+	if lockFile != nil {
+		buildPlan.PinImages(lockFile.Images)
+		warnStaleLockFile(lockFile, resolvedPackages, logger)
+	}
+
+	if options.PinImages {
+		pinImageDigests(buildPlan, logger)
+	}
+
+	logUnpinnedImages(buildPlan, lockFile, logger)
+
 	if !ValidatePlan(buildPlan, app, logger, &ValidatePlanOptions{
 		ErrorMissingStartCommand: options.ErrorMissingStartCommand,
 		ProviderToUse:            providerToUse,
@@ -133,12 +180,30 @@ func GenerateBuildPlan(app *app.App, env *app.Environment, options *GenerateBuil
 		Metadata:          ctx.Metadata.Properties,
 		DetectedProviders: []string{detectedProviderName},
 		Logs:              logger.Logs,
+		Lint:              lintBuildPlan(ctx, buildPlan),
 		Success:           true,
 	}
 
 	return buildResult
 }
 
+// lintBuildPlan runs the lint rules against a generated plan.
+// Only unreachable steps from the user config are reported, since providers add steps that are only used by some apps.
+func lintBuildPlan(ctx *generate.GenerateContext, buildPlan *plan.BuildPlan) []lint.Finding {
+	unreachableSteps := []string{}
+	for _, name := range ctx.UnreachableSteps {
+		if _, ok := ctx.Config.Steps[name]; ok {
+			unreachableSteps = append(unreachableSteps, name)
+		}
+	}
+
+	return lint.Lint(&lint.Context{
+		Plan:             buildPlan,
+		App:              ctx.App,
+		UnreachableSteps: unreachableSteps,
+	})
+}
+
 // GetConfig merges the options, environment, and file config into a single config
 func GetConfig(app *app.App, env *app.Environment, options *GenerateBuildPlanOptions, logger *logger.Logger) (*c.Config, error) {
 	optionsConfig := GenerateConfigFromOptions(options)
@@ -220,6 +285,10 @@ func GenerateConfigFromEnvironment(env *app.Environment) *c.Config {
 		config.Deploy.StartCmd = startCmdVar
 	}
 
+	if releaseCmdVar, _ := env.GetConfigVariable("RELEASE_CMD"); releaseCmdVar != "" {
+		config.Deploy.ReleaseCmd = releaseCmdVar
+	}
+
 	if envPackages, _ := env.GetConfigVariable("PACKAGES"); envPackages != "" {
 		config.Packages = utils.ParsePackageWithVersion(strings.Split(envPackages, " "))
 	}
@@ -232,6 +301,18 @@ func GenerateConfigFromEnvironment(env *app.Environment) *c.Config {
 		config.Deploy.AptPackages = strings.Split(envAptPackages, " ")
 	}
 
+	if nodeWorkspace, _ := env.GetConfigVariable("NODE_WORKSPACE"); nodeWorkspace != "" {
+		config.Node = &c.NodeConfig{Workspace: nodeWorkspace}
+	}
+
+	if pythonPackage, _ := env.GetConfigVariable("PYTHON_PACKAGE"); pythonPackage != "" {
+		config.Python = &c.PythonConfig{Package: pythonPackage}
+	}
+
+	if env.IsConfigVariableTruthy("DOTENV_PRODUCTION") {
+		config.Dotenv = &c.DotenvConfig{Production: true}
+	}
+
 	config.Secrets = append(config.Secrets, slices.Sorted(maps.Keys(env.Variables))...)
 
 	return config
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/railwayapp/railpack/core/plan"
+	"github.com/railwayapp/railpack/core/resolver"
+)
+
+// DiffBuildResults returns the semantic differences between two build results.
+// This includes all plan differences as well as changes to the resolved package versions.
+// Saved plans do not include the resolved packages, so packages are only compared when both results have them.
+func DiffBuildResults(oldResult, newResult *BuildResult) []plan.Change {
+	if oldResult == nil {
+		oldResult = &BuildResult{}
+	}
+	if newResult == nil {
+		newResult = &BuildResult{}
+	}
+
+	changes := plan.Diff(oldResult.Plan, newResult.Plan)
+	if oldResult.ResolvedPackages != nil && newResult.ResolvedPackages != nil {
+		changes = append(changes, diffResolvedPackages(oldResult.ResolvedPackages, newResult.ResolvedPackages)...)
+	}
+
+	return changes
+}
+
+func diffResolvedPackages(oldPackages, newPackages map[string]*resolver.ResolvedPackage) []plan.Change {
+	changes := []plan.Change{}
+
+	names := slices.Sorted(maps.Keys(oldPackages))
+	for name := range newPackages {
+		if _, ok := oldPackages[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		oldVersion, inOld := resolvedVersion(oldPackages, name)
+		newVersion, inNew := resolvedVersion(newPackages, name)
+		path := "packages." + name
+
+		switch {
+		case inOld && !inNew:
+			changes = append(changes, plan.Change{Type: plan.ChangeRemoved, Path: path, Old: oldVersion})
+		case !inOld && inNew:
+			changes = append(changes, plan.Change{Type: plan.ChangeAdded, Path: path, New: newVersion})
+		case oldVersion != newVersion:
+			changes = append(changes, plan.Change{Type: plan.ChangeModified, Path: path, Old: oldVersion, New: newVersion})
+		}
+	}
+
+	return changes
+}
+
+func resolvedVersion(packages map[string]*resolver.ResolvedPackage, name string) (string, bool) {
+	pkg, ok := packages[name]
+	if !ok || pkg == nil {
+		return "", false
+	}
+
+	if pkg.ResolvedVersion != nil {
+		return *pkg.ResolvedVersion, true
+	}
+	if pkg.RequestedVersion != nil {
+		return *pkg.RequestedVersion, true
+	}
+
+	return "", true
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/railwayapp/railpack/core/plan"
+	"github.com/railwayapp/railpack/core/resolver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffResolvedPackages(t *testing.T) {
+	resolved := func(version string) *resolver.ResolvedPackage {
+		return &resolver.ResolvedPackage{ResolvedVersion: &version}
+	}
+
+	oldPackages := map[string]*resolver.ResolvedPackage{
+		"node":   resolved("20.18.0"),
+		"python": resolved("3.12.7"),
+		"bun":    resolved("1.1.30"),
+	}
+	newPackages := map[string]*resolver.ResolvedPackage{
+		"node":   resolved("22.11.0"),
+		"python": resolved("3.12.7"),
+		"go":     resolved("1.23.2"),
+	}
+
+	require.Equal(t, []plan.Change{
+		{Type: plan.ChangeRemoved, Path: "packages.bun", Old: "1.1.30"},
+		{Type: plan.ChangeAdded, Path: "packages.go", New: "1.23.2"},
+		{Type: plan.ChangeModified, Path: "packages.node", Old: "20.18.0", New: "22.11.0"},
+	}, diffResolvedPackages(oldPackages, newPackages))
+
+	require.Empty(t, diffResolvedPackages(oldPackages, oldPackages))
+}
+
+func TestDiffBuildResults_SavedPlan(t *testing.T) {
+	version := "22.11.0"
+	buildPlan := plan.NewBuildPlan()
+
+	// A saved plan does not have the resolved packages of the build
+	savedPlan := &BuildResult{Plan: buildPlan}
+	generated := &BuildResult{
+		Plan: buildPlan,
+		ResolvedPackages: map[string]*resolver.ResolvedPackage{
+			"node": {ResolvedVersion: &version},
+		},
+	}
+
+	require.Empty(t, DiffBuildResults(savedPlan, generated))
+	require.Empty(t, DiffBuildResults(generated, savedPlan))
+}
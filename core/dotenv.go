@@ -0,0 +1,79 @@
+package core
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/app"
+	c "github.com/railwayapp/railpack/core/config"
+	"github.com/railwayapp/railpack/core/logger"
+)
+
+var (
+	// The .env files loaded in dev mode, from lowest to highest precedence
+	devDotenvFiles = []string{".env", ".env.development", ".env.local", ".env.development.local"}
+
+	// The .env files loaded in production builds when enabled
+	productionDotenvFiles = []string{".env.production"}
+)
+
+// applyDotenv loads variables from the .env files in the app.
+//
+// In dev mode the variables are added to the deploy variables, below any variables in the config.
+// In production builds .env.production is only loaded if enabled, and its variables are added as secrets
+// so that the values are never written to the image. Variables that are marked as non-secret are
+// returned instead, to be set directly on the build steps. Variables from the environment always take precedence.
+func applyDotenv(a *app.App, env *app.Environment, config *c.Config, dev bool, log *logger.Logger) (map[string]string, error) {
+	buildVariables := map[string]string{}
+
+	if dev {
+		variables, files, err := a.ReadDotenvFiles(devDotenvFiles)
+		if err != nil || len(files) == 0 {
+			return buildVariables, err
+		}
+
+		log.LogInfo("Loaded %d variables from %s", len(variables), strings.Join(files, ", "))
+
+		maps.Copy(variables, config.Deploy.Variables)
+		config.Deploy.Variables = variables
+		return buildVariables, nil
+	}
+
+	if config.Dotenv == nil || !config.Dotenv.Production {
+		return buildVariables, nil
+	}
+
+	variables, files, err := a.ReadDotenvFiles(productionDotenvFiles)
+	if err != nil || len(files) == 0 {
+		return buildVariables, err
+	}
+
+	log.LogInfo("Loaded %d build variables from %s", len(variables), strings.Join(files, ", "))
+
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		if _, ok := env.Variables[name]; ok {
+			continue
+		}
+
+		if isNonSecret(name, config.Dotenv.NonSecret) {
+			buildVariables[name] = variables[name]
+		} else {
+			env.SetVariable(name, variables[name])
+			config.Secrets = append(config.Secrets, name)
+		}
+	}
+
+	return buildVariables, nil
+}
+
+// isNonSecret checks if a variable name matches one of the non-secret patterns.
+// A pattern with a trailing * matches every name with that prefix.
+func isNonSecret(name string, patterns []string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			return strings.HasPrefix(name, prefix)
+		}
+		return name == pattern
+	})
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/railwayapp/railpack/core/app"
+	c "github.com/railwayapp/railpack/core/config"
+	"github.com/railwayapp/railpack/core/logger"
+	testingUtils "github.com/railwayapp/railpack/core/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func createDotenvApp(t *testing.T, files map[string]string) *app.App {
+	a, err := app.NewApp(testingUtils.CreateAppDir(t, files))
+	require.NoError(t, err)
+	return a
+}
+
+func TestApplyDotenvDev(t *testing.T) {
+	a := createDotenvApp(t, map[string]string{
+		".env":                   "A=env\nB=env\nC=env\nD=env\n",
+		".env.development":       "B=development\n",
+		".env.local":             "C=local\n",
+		".env.development.local": "D=development-local\n",
+		".env.production":        "E=production\n",
+	})
+
+	config := c.EmptyConfig()
+	config.Deploy.Variables = map[string]string{"A": "config"}
+
+	buildVariables, err := applyDotenv(a, app.NewEnvironment(nil), config, true, logger.NewLogger())
+	require.NoError(t, err)
+	require.Empty(t, buildVariables)
+
+	require.Equal(t, map[string]string{
+		"A": "config",
+		"B": "development",
+		"C": "local",
+		"D": "development-local",
+	}, config.Deploy.Variables)
+}
+
+func TestApplyDotenvProduction(t *testing.T) {
+	a := createDotenvApp(t, map[string]string{
+		".env":            "LOCAL_ONLY=1\n",
+		".env.production": "API_KEY=secret\nNEXT_PUBLIC_URL=https://example.com\nFROM_ENV=file\n",
+	})
+
+	// .env.production is not loaded unless enabled
+	config := c.EmptyConfig()
+	env := app.NewEnvironment(nil)
+	buildVariables, err := applyDotenv(a, env, config, false, logger.NewLogger())
+	require.NoError(t, err)
+	require.Empty(t, buildVariables)
+	require.Empty(t, env.Variables)
+
+	config.Dotenv = &c.DotenvConfig{Production: true, NonSecret: []string{"NEXT_PUBLIC_*"}}
+	env = app.NewEnvironment(&map[string]string{"FROM_ENV": "env"})
+
+	buildVariables, err = applyDotenv(a, env, config, false, logger.NewLogger())
+	require.NoError(t, err)
+
+	// Secret values are only available as secrets, non-secret values are set directly
+	require.Equal(t, map[string]string{"NEXT_PUBLIC_URL": "https://example.com"}, buildVariables)
+	require.Equal(t, []string{"API_KEY"}, config.Secrets)
+	require.Equal(t, "secret", env.GetVariable("API_KEY"))
+	require.Equal(t, "env", env.GetVariable("FROM_ENV"))
+	require.Empty(t, config.Deploy.Variables)
+}
+
+func TestDotenvProductionBuildPlan(t *testing.T) {
+	a := createDotenvApp(t, map[string]string{
+		"start.sh":        "echo hello\n",
+		".env.production": "API_KEY=secret\nPUBLIC_NAME=app\n",
+		"railpack.json":   `{"dotenv": {"production": true, "nonSecret": ["PUBLIC_NAME"]}}`,
+	})
+
+	env := app.NewEnvironment(nil)
+	buildResult := GenerateBuildPlan(a, env, &GenerateBuildPlanOptions{})
+	require.True(t, buildResult.Success, buildResult.Logs)
+
+	require.Contains(t, buildResult.Plan.Secrets, "API_KEY")
+	for _, step := range buildResult.Plan.Steps {
+		for name, value := range step.Variables {
+			require.NotEqual(t, "secret", value, "secret value set as variable %s in step %s", name, step.Name)
+		}
+	}
+	require.NotContains(t, buildResult.Plan.Deploy.Variables, "API_KEY")
+}
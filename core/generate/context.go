@@ -49,6 +49,15 @@ type GenerateContext struct {
 
 	// Dev indicates the plan is being generated in development mode
 	Dev bool
+
+	// Debug indicates the development plan starts the app under a debugger
+	Debug bool
+
+	// Variables set on every command step that does not already set them (e.g. non-secret variables from .env files)
+	BuildVariables map[string]string
+
+	// Steps that were removed from the generated plan because deploy does not depend on them
+	UnreachableSteps []string
 }
 
 type Command interface {
@@ -158,6 +167,7 @@ func (c *GenerateContext) Generate() (*plan.BuildPlan, map[string]*resolver.Reso
 	buildPlan.Secrets = utils.RemoveDuplicates(c.Secrets)
 	c.Deploy.Build(buildPlan, buildStepOptions)
 
+	c.UnreachableSteps = buildPlan.UnreachableSteps()
 	buildPlan.Normalize()
 
 	return buildPlan, resolvedPackages, nil
@@ -181,6 +191,21 @@ func (c *GenerateContext) applyPackagesFromConfig() {
 	}
 }
 
+func (c *GenerateContext) applyBuildVariables() {
+	for _, step := range c.Steps {
+		commandStepBuilder, ok := step.(*CommandStepBuilder)
+		if !ok {
+			continue
+		}
+
+		for name, value := range c.BuildVariables {
+			if _, exists := commandStepBuilder.Variables[name]; !exists {
+				commandStepBuilder.Variables[name] = value
+			}
+		}
+	}
+}
+
 func (c *GenerateContext) applyConfig() {
 	c.applyPackagesFromConfig()
 
@@ -195,6 +220,10 @@ func (c *GenerateContext) applyConfig() {
 			c.Deploy.StartCmd = c.Config.Deploy.StartCmd
 		}
 
+		if c.Config.Deploy.ReleaseCmd != "" && !c.Dev {
+			c.Deploy.ReleaseCmd = c.Config.Deploy.ReleaseCmd
+		}
+
 		c.Deploy.AptPackages = plan.SpreadStrings(c.Config.Deploy.AptPackages, c.Deploy.AptPackages)
 		c.Deploy.DeployInputs = plan.Spread(c.Config.Deploy.Inputs, c.Deploy.DeployInputs)
 		if len(c.Config.Deploy.Paths) > 0 {
@@ -239,4 +268,6 @@ func (c *GenerateContext) applyConfig() {
 			c.Deploy.AddInputs([]plan.Layer{plan.NewStepLayer(name, filter)})
 		}
 	}
+
+	c.applyBuildVariables()
 }
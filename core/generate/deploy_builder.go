@@ -9,10 +9,12 @@ type DeployBuilder struct {
 	DeployInputs []plan.Layer
 	StartCmd     string
 	StartCmdHost string
+	ReleaseCmd   string
 	RequiredPort string
 	Variables    map[string]string
 	Paths        []string
 	AptPackages  []string
+	NoShell      bool
 }
 
 func NewDeployBuilder() *DeployBuilder {
@@ -21,6 +23,7 @@ func NewDeployBuilder() *DeployBuilder {
 		DeployInputs: []plan.Layer{},
 		StartCmd:     "",
 		StartCmdHost: "",
+		ReleaseCmd:   "",
 		RequiredPort: "",
 		Variables:    map[string]string{},
 		Paths:        []string{},
@@ -60,7 +63,9 @@ func (b *DeployBuilder) Build(p *plan.BuildPlan, options *BuildStepOptions) {
 	p.Deploy.Inputs = append(p.Deploy.Inputs, b.DeployInputs...)
 	p.Deploy.StartCmd = b.StartCmd
 	p.Deploy.StartCmdHost = b.StartCmdHost
+	p.Deploy.ReleaseCmd = b.ReleaseCmd
 	p.Deploy.RequiredPort = b.RequiredPort
 	p.Deploy.Variables = b.Variables
 	p.Deploy.Paths = b.Paths
+	p.Deploy.NoShell = b.NoShell
 }
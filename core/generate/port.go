@@ -0,0 +1,109 @@
+package generate
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PortSourceDefault is the source of a port that is the framework default rather than read from the app
+	PortSourceDefault = "default"
+)
+
+var (
+	// Flags that take the port as their value (e.g. --port 3000 or --port=3000)
+	portFlags = []string{"--port", "-p", "--server.port", "--server-port", "--http-port"}
+
+	// Flags that take an address with the port as their value (e.g. --bind 0.0.0.0:8000 or php -S 0.0.0.0:8000)
+	bindFlags = []string{"--bind", "-b", "--listen", "-S"}
+
+	// Placeholders with a default value, e.g. ${PORT:8080} or ${PORT:-8080}
+	portPlaceholderRegex = regexp.MustCompile(`^\$\{[^:}]+:-?(\d+)\}$`)
+
+	// The host part of an address, which may be an IPv6 address in brackets
+	addressHostRegex = regexp.MustCompile(`^(?:\[[^\]]*\]|[^:$\[]*):`)
+)
+
+// SetRequiredPort sets the port the app listens on in development
+// and records where the port was found in the metadata
+func (c *GenerateContext) SetRequiredPort(port string, source string) {
+	if port == "" {
+		return
+	}
+
+	c.Deploy.RequiredPort = port
+	c.Metadata.Set("requiredPortSource", source)
+}
+
+// AddDebugPort adds the port a debugger listens on to the ports required in development
+func (c *GenerateContext) AddDebugPort(port string) {
+	ports := []string{}
+	if c.Deploy.RequiredPort != "" {
+		ports = strings.Split(c.Deploy.RequiredPort, ",")
+	}
+
+	if !slices.Contains(ports, port) {
+		ports = append(ports, port)
+	}
+
+	c.Deploy.RequiredPort = strings.Join(ports, ",")
+	c.Metadata.Set("debugPort", port)
+}
+
+// PortFromCommand returns the port set by the arguments of a shell command.
+// This handles port flags (--port 3000, -p=3000), bind addresses (--bind 0.0.0.0:8000),
+// and the address argument of Django's runserver (runserver 0.0.0.0:8000).
+func PortFromCommand(cmd string) string {
+	args := strings.Fields(cmd)
+
+	for i, arg := range args {
+		flag, value, hasValue := strings.Cut(arg, "=")
+		if !hasValue && i+1 < len(args) {
+			value = args[i+1]
+		}
+
+		switch {
+		case slices.Contains(portFlags, flag):
+			if port := ParsePort(value); port != "" {
+				return port
+			}
+		case slices.Contains(bindFlags, flag):
+			if port := portFromAddress(value); port != "" {
+				return port
+			}
+		case arg == "runserver" && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-"):
+			if port := portFromAddress(args[i+1]); port != "" {
+				return port
+			}
+		}
+	}
+
+	return ""
+}
+
+// ParsePort returns the port if the value is a valid port number,
+// or the default of a placeholder such as ${PORT:8080} or ${PORT:-8080}
+func ParsePort(value string) string {
+	value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+	if matches := portPlaceholderRegex.FindStringSubmatch(value); matches != nil {
+		value = matches[1]
+	}
+
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return ""
+	}
+
+	return strconv.Itoa(port)
+}
+
+// portFromAddress returns the port of a host:port address, or the value itself if it is only a port
+func portFromAddress(address string) string {
+	if port := ParsePort(address); port != "" {
+		return port
+	}
+	return ParsePort(addressHostRegex.ReplaceAllString(address, ""))
+}
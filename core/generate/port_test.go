@@ -0,0 +1,58 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortFromCommand(t *testing.T) {
+	tests := []struct {
+		cmd      string
+		expected string
+	}{
+		{cmd: "vite --port 3001", expected: "3001"},
+		{cmd: "next dev -p 4000", expected: "4000"},
+		{cmd: "astro dev --port=4322 --host", expected: "4322"},
+		{cmd: "uvicorn main:app --reload --host 0.0.0.0 --port 8080", expected: "8080"},
+		{cmd: "python manage.py runserver 0.0.0.0:8001", expected: "8001"},
+		{cmd: "python manage.py runserver 9000", expected: "9000"},
+		{cmd: "gunicorn app:app --bind 0.0.0.0:5001", expected: "5001"},
+		{cmd: "streamlit run main.py --server.port 8502", expected: "8502"},
+		{cmd: "php -S 0.0.0.0:${PORT:-8000} -t public", expected: "8000"},
+		{cmd: "php artisan serve --host 0.0.0.0 --port ${PORT:-8000}", expected: "8000"},
+		{cmd: "python manage.py runserver", expected: ""},
+		{cmd: "vite --port $PORT", expected: ""},
+		{cmd: "node server.js -p 99999", expected: ""},
+		{cmd: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cmd, func(t *testing.T) {
+			require.Equal(t, tt.expected, PortFromCommand(tt.cmd))
+		})
+	}
+}
+
+func TestParsePort(t *testing.T) {
+	require.Equal(t, "8080", ParsePort("8080"))
+	require.Equal(t, "8080", ParsePort(" \"8080\" "))
+	require.Equal(t, "9090", ParsePort("${PORT:9090}"))
+	require.Equal(t, "9090", ParsePort("${PORT:-9090}"))
+	require.Equal(t, "", ParsePort("${PORT}"))
+	require.Equal(t, "", ParsePort("0"))
+	require.Equal(t, "", ParsePort("http"))
+}
+
+func TestAddDebugPort(t *testing.T) {
+	ctx := CreateTestContext(t, "../../examples/node-npm")
+
+	ctx.AddDebugPort("9229")
+	require.Equal(t, "9229", ctx.Deploy.RequiredPort)
+
+	ctx.SetRequiredPort("3000", PortSourceDefault)
+	ctx.AddDebugPort("9229")
+	ctx.AddDebugPort("9229")
+	require.Equal(t, "3000,9229", ctx.Deploy.RequiredPort)
+	require.Equal(t, "9229", ctx.Metadata.Get("debugPort"))
+}
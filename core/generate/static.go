@@ -0,0 +1,324 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/config"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+const (
+	StaticHeadersFile   = "_headers"
+	StaticRedirectsFile = "_redirects"
+)
+
+var (
+	precompressExtensions = []string{"html", "css", "js", "mjs", "json", "svg", "xml", "txt", "wasm"}
+
+	// redirectPlaceholderRegex matches the placeholders in a redirect target (e.g. :splat or :id)
+	redirectPlaceholderRegex = regexp.MustCompile(`:([a-zA-Z_][a-zA-Z0-9_]*)`)
+)
+
+// StaticServer is the configuration of a static file server, rendered into the Caddyfile template as STATIC
+type StaticServer struct {
+	Headers     []StaticHeaderRule
+	Redirects   []StaticPathRule
+	Rewrites    []StaticPathRule
+	Proxies     []StaticProxyRule
+	Precompress bool
+}
+
+type StaticHeaderRule struct {
+	Path    string
+	Headers []StaticHeader
+}
+
+type StaticHeader struct {
+	Name  string
+	Value string
+}
+
+// StaticPathRule is a redirect or rewrite. The path is matched with a regular expression named Name,
+// so the captured segments can be used in To (e.g. {re.redirect0.1}).
+type StaticPathRule struct {
+	Name   string
+	Regex  string
+	To     string
+	Status int
+	Force  bool
+}
+
+type StaticProxyRule struct {
+	// Directive is handle_path if the matched prefix is stripped before proxying, otherwise handle
+	Directive string
+	Path      string
+	Rewrite   string
+	Upstream  string
+}
+
+// NewStaticServer creates the static server configuration from the static config
+// and any _headers and _redirects files (the Netlify format) in the given directories
+func (c *GenerateContext) NewStaticServer(dirs []string) (*StaticServer, error) {
+	staticConfig := c.Config.Static
+	if staticConfig == nil {
+		staticConfig = &config.StaticConfig{}
+	}
+
+	server := &StaticServer{
+		Precompress: staticConfig.Precompress,
+	}
+
+	headers := map[string]map[string]string{}
+	for pattern, values := range staticConfig.Headers {
+		headers[pattern] = maps.Clone(values)
+	}
+	for pattern, value := range staticConfig.CacheControl {
+		if headers[pattern] == nil {
+			headers[pattern] = map[string]string{}
+		}
+		headers[pattern]["Cache-Control"] = value
+	}
+	for _, pattern := range slices.Sorted(maps.Keys(headers)) {
+		server.Headers = append(server.Headers, newStaticHeaderRule(pattern, headers[pattern]))
+	}
+
+	redirects := slices.Clone(staticConfig.Redirects)
+
+	for _, dir := range dirs {
+		if file := path.Join(dir, StaticHeadersFile); c.App.HasFile(file) {
+			contents, err := c.App.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			c.Logger.LogInfo("Using headers from %s", file)
+			server.Headers = append(server.Headers, parseHeadersFile(contents)...)
+		}
+
+		if file := path.Join(dir, StaticRedirectsFile); c.App.HasFile(file) {
+			contents, err := c.App.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			fileRedirects, err := parseRedirectsFile(contents)
+			if err != nil {
+				return nil, fmt.Errorf("error reading %s: %w", file, err)
+			}
+
+			c.Logger.LogInfo("Using redirects from %s", file)
+			redirects = append(redirects, fileRedirects...)
+		}
+	}
+
+	for _, pattern := range slices.Sorted(maps.Keys(staticConfig.Proxy)) {
+		server.Proxies = append(server.Proxies, StaticProxyRule{
+			Directive: "handle",
+			Path:      pattern,
+			Upstream:  staticConfig.Proxy[pattern],
+		})
+	}
+
+	for _, redirect := range redirects {
+		if err := server.addRedirect(redirect); err != nil {
+			return nil, err
+		}
+	}
+
+	return server, nil
+}
+
+// AddPrecompression compresses the text files in dir with gzip and brotli at the end of the build,
+// so Caddy can serve the precompressed files. Files that contain skipContent are changed when the
+// container starts, so they are left to be compressed by Caddy on each request.
+func (s *StaticServer) AddPrecompression(miseStep *MiseStepBuilder, build *CommandStepBuilder, dir string, skipContent string) {
+	if !s.Precompress {
+		return
+	}
+
+	names := []string{}
+	for _, ext := range precompressExtensions {
+		names = append(names, fmt.Sprintf(`-name "*.%s"`, ext))
+	}
+
+	skip := ""
+	if skipContent != "" {
+		skip = fmt.Sprintf(`! -exec grep -q "%s" {} \; `, skipContent)
+	}
+
+	miseStep.AddSupportingAptPackage("brotli")
+	build.AddCommand(plan.NewExecShellCommand(fmt.Sprintf(
+		`find %s -type f \( %s \) %s-exec gzip -9 -k -f {} + -exec brotli -q 11 -k -f {} +`,
+		dir, strings.Join(names, " -o "), skip,
+	)))
+}
+
+func (s *StaticServer) addRedirect(redirect config.StaticRedirect) error {
+	status := redirect.Status
+	if status == 0 {
+		status = 301
+	}
+
+	if !strings.HasPrefix(redirect.From, "/") {
+		return fmt.Errorf("redirect from `%s` must start with /", redirect.From)
+	}
+
+	isURL := strings.HasPrefix(redirect.To, "http://") || strings.HasPrefix(redirect.To, "https://")
+	if status == 200 && isURL {
+		proxy, err := newRedirectProxyRule(redirect)
+		if err != nil {
+			return err
+		}
+		s.Proxies = append(s.Proxies, *proxy)
+		return nil
+	}
+
+	if status == 200 {
+		s.Rewrites = append(s.Rewrites, newStaticPathRule(fmt.Sprintf("rewrite%d", len(s.Rewrites)), redirect, status))
+	} else {
+		s.Redirects = append(s.Redirects, newStaticPathRule(fmt.Sprintf("redirect%d", len(s.Redirects)), redirect, status))
+	}
+
+	return nil
+}
+
+// newStaticPathRule converts the from path into a regular expression. Named segments (:id) and a
+// trailing * (:splat) are captured and replaced in the target.
+func newStaticPathRule(name string, redirect config.StaticRedirect, status int) StaticPathRule {
+	captures := map[string]int{}
+	segments := strings.Split(redirect.From, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "*":
+			captures["splat"] = len(captures) + 1
+			segments[i] = "(.*)"
+		case strings.HasPrefix(segment, ":"):
+			captures[segment[1:]] = len(captures) + 1
+			segments[i] = "([^/]+)"
+		default:
+			segments[i] = regexp.QuoteMeta(segment)
+		}
+	}
+
+	to := redirectPlaceholderRegex.ReplaceAllStringFunc(redirect.To, func(placeholder string) string {
+		if index, ok := captures[placeholder[1:]]; ok {
+			return fmt.Sprintf("{re.%s.%d}", name, index)
+		}
+		return placeholder
+	})
+
+	return StaticPathRule{
+		Name:   name,
+		Regex:  "^" + strings.Join(segments, "/") + "$",
+		To:     to,
+		Status: status,
+		Force:  redirect.Force,
+	}
+}
+
+// newRedirectProxyRule proxies the matching requests to the URL of the redirect.
+// The path matched by * is appended to the upstream path if it ends with :splat.
+func newRedirectProxyRule(redirect config.StaticRedirect) (*StaticProxyRule, error) {
+	upstream, err := url.Parse(redirect.To)
+	if err != nil || upstream.Host == "" {
+		return nil, fmt.Errorf("invalid proxy url `%s`", redirect.To)
+	}
+
+	rule := &StaticProxyRule{
+		Directive: "handle",
+		Path:      redirect.From,
+		Rewrite:   upstream.Path,
+		Upstream:  upstream.Scheme + "://" + upstream.Host,
+	}
+
+	if strings.HasSuffix(redirect.From, "/*") && strings.HasSuffix(upstream.Path, "/:splat") {
+		rule.Directive = "handle_path"
+		rule.Rewrite = strings.TrimSuffix(upstream.Path, "/:splat") + "{uri}"
+		if rule.Rewrite == "{uri}" {
+			rule.Rewrite = ""
+		}
+	}
+
+	return rule, nil
+}
+
+// parseHeadersFile parses a _headers file. Each path is followed by indented `Name: value` lines.
+func parseHeadersFile(contents string) []StaticHeaderRule {
+	rules := []StaticHeaderRule{}
+
+	for _, line := range strings.Split(contents, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if trimmed == line {
+			rules = append(rules, StaticHeaderRule{Path: trimmed})
+			continue
+		}
+
+		name, value, ok := strings.Cut(trimmed, ":")
+		if !ok || len(rules) == 0 {
+			continue
+		}
+
+		rule := &rules[len(rules)-1]
+		rule.Headers = append(rule.Headers, StaticHeader{
+			Name:  strings.TrimSpace(name),
+			Value: escapeHeaderValue(strings.TrimSpace(value)),
+		})
+	}
+
+	return rules
+}
+
+// parseRedirectsFile parses a _redirects file. Each line is `from to [status]`, where a status
+// ending with ! forces the rule.
+func parseRedirectsFile(contents string) ([]config.StaticRedirect, error) {
+	redirects := []config.StaticRedirect{}
+
+	for i, line := range strings.Split(contents, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid line %d: expected `from to [status]`", i+1)
+		}
+
+		redirect := config.StaticRedirect{From: fields[0], To: fields[1]}
+		if len(fields) > 2 {
+			status, err := strconv.Atoi(strings.TrimSuffix(fields[2], "!"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid status `%s` on line %d", fields[2], i+1)
+			}
+
+			redirect.Status = status
+			redirect.Force = strings.HasSuffix(fields[2], "!")
+		}
+
+		redirects = append(redirects, redirect)
+	}
+
+	return redirects, nil
+}
+
+func newStaticHeaderRule(pattern string, values map[string]string) StaticHeaderRule {
+	rule := StaticHeaderRule{Path: pattern}
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		rule.Headers = append(rule.Headers, StaticHeader{Name: name, Value: escapeHeaderValue(values[name])})
+	}
+	return rule
+}
+
+func escapeHeaderValue(value string) string {
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
@@ -0,0 +1,73 @@
+package generate
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+const (
+	// StaticBaseDistroless deploys on the distroless static image, which includes CA certificates and tzdata
+	StaticBaseDistroless = "distroless"
+
+	// StaticBaseScratch deploys on an empty image with the CA certificates of the build
+	StaticBaseScratch = "scratch"
+
+	// StaticBaseRuntime deploys on the Railpack runtime image like other apps
+	StaticBaseRuntime = "runtime"
+
+	CACertificatesPath = "/etc/ssl/certs/ca-certificates.crt"
+)
+
+// GetStaticBase returns the minimal base image a statically linked binary is deployed on.
+// This is opted into with RAILPACK_STATIC_BASE and is empty if the runtime image should be used,
+// which is the default and is always the case in development or when runtime apt packages are configured.
+func (c *GenerateContext) GetStaticBase() string {
+	if c.Dev {
+		return ""
+	}
+
+	if c.Config.Deploy != nil && len(c.Config.Deploy.AptPackages) > 0 {
+		return ""
+	}
+
+	base, varName := c.Env.GetConfigVariable("STATIC_BASE")
+	switch strings.ToLower(base) {
+	case StaticBaseDistroless:
+		return StaticBaseDistroless
+	case StaticBaseScratch:
+		return StaticBaseScratch
+	case "", StaticBaseRuntime:
+		return ""
+	default:
+		c.Logger.LogWarn("Unknown %s `%s`. Expected %s, %s, or %s", varName, base, StaticBaseDistroless, StaticBaseScratch, StaticBaseRuntime)
+		return ""
+	}
+}
+
+// UseStaticBase deploys only the given paths of a step on a minimal base image.
+// The image does not have a shell, so the start command is run directly.
+func (b *DeployBuilder) UseStaticBase(base string, stepName string, paths []string) {
+	if base == StaticBaseScratch {
+		b.useMinimalBase(plan.ScratchImage, stepName, append(slices.Clone(paths), CACertificatesPath))
+	} else {
+		b.useMinimalBase(plan.DistrolessStaticImage, stepName, paths)
+	}
+}
+
+// UseGlibcBase deploys only the given paths of a step on the distroless cc image,
+// for binaries that are dynamically linked against glibc and cannot run on scratch or the static image.
+func (b *DeployBuilder) UseGlibcBase(stepName string, paths []string) {
+	b.useMinimalBase(plan.DistrolessCCImage, stepName, paths)
+}
+
+func (b *DeployBuilder) useMinimalBase(image string, stepName string, paths []string) {
+	b.Base = plan.NewImageLayer(image)
+	b.AddInputs([]plan.Layer{
+		plan.NewStepLayer(stepName, plan.Filter{
+			Include: paths,
+		}),
+	})
+	b.NoShell = true
+}
@@ -0,0 +1,105 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/railwayapp/railpack/core/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRedirectsFile(t *testing.T) {
+	redirects, err := parseRedirectsFile(`# comment
+/old /new
+/blog/* /news/:splat 302
+/app/* /index.html 200!
+`)
+	require.NoError(t, err)
+	require.Equal(t, []config.StaticRedirect{
+		{From: "/old", To: "/new"},
+		{From: "/blog/*", To: "/news/:splat", Status: 302},
+		{From: "/app/*", To: "/index.html", Status: 200, Force: true},
+	}, redirects)
+
+	_, err = parseRedirectsFile("/old")
+	require.Error(t, err)
+
+	_, err = parseRedirectsFile("/old /new moved")
+	require.Error(t, err)
+}
+
+func TestParseHeadersFile(t *testing.T) {
+	rules := parseHeadersFile(`/*
+  X-Frame-Options: DENY
+  # comment
+/assets/*
+  Cache-Control: public, max-age=31536000, immutable
+`)
+
+	require.Equal(t, []StaticHeaderRule{
+		{Path: "/*", Headers: []StaticHeader{{Name: "X-Frame-Options", Value: "DENY"}}},
+		{Path: "/assets/*", Headers: []StaticHeader{{Name: "Cache-Control", Value: "public, max-age=31536000, immutable"}}},
+	}, rules)
+}
+
+func TestNewStaticPathRule(t *testing.T) {
+	rule := newStaticPathRule("redirect0", config.StaticRedirect{From: "/users/:id/posts/*", To: "/u/:id/:splat"}, 301)
+	require.Equal(t, `^/users/([^/]+)/posts/(.*)$`, rule.Regex)
+	require.Equal(t, "/u/{re.redirect0.1}/{re.redirect0.2}", rule.To)
+
+	rule = newStaticPathRule("redirect1", config.StaticRedirect{From: "/file.html", To: "/file"}, 301)
+	require.Equal(t, `^/file\.html$`, rule.Regex)
+}
+
+func TestNewRedirectProxyRule(t *testing.T) {
+	rule, err := newRedirectProxyRule(config.StaticRedirect{From: "/api/*", To: "https://api.example.com/:splat", Status: 200})
+	require.NoError(t, err)
+	require.Equal(t, &StaticProxyRule{Directive: "handle_path", Path: "/api/*", Upstream: "https://api.example.com"}, rule)
+
+	rule, err = newRedirectProxyRule(config.StaticRedirect{From: "/api/*", To: "https://api.example.com/v1/:splat", Status: 200})
+	require.NoError(t, err)
+	require.Equal(t, "/v1{uri}", rule.Rewrite)
+
+	rule, err = newRedirectProxyRule(config.StaticRedirect{From: "/health", To: "http://api:3000/status", Status: 200})
+	require.NoError(t, err)
+	require.Equal(t, &StaticProxyRule{Directive: "handle", Path: "/health", Rewrite: "/status", Upstream: "http://api:3000"}, rule)
+}
+
+func TestNewStaticServer(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "public"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "public", "_redirects"), []byte("/api/* https://api.example.com/:splat 200\n/* /index.html 200\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "public", "_headers"), []byte("/*\n  X-Frame-Options: DENY\n"), 0644))
+
+	ctx := CreateTestContext(t, dir)
+	ctx.Config.Static = &config.StaticConfig{
+		Headers:      map[string]map[string]string{"/assets/*": {"X-Asset": "true"}},
+		CacheControl: map[string]string{"/assets/*": "immutable", "/index.html": "no-cache"},
+		Redirects:    []config.StaticRedirect{{From: "/old", To: "/new"}},
+		Proxy:        map[string]string{"/graphql": "{$API_URL}"},
+		Precompress:  true,
+	}
+
+	server, err := ctx.NewStaticServer([]string{"public", "static"})
+	require.NoError(t, err)
+
+	require.Equal(t, []StaticHeaderRule{
+		{Path: "/assets/*", Headers: []StaticHeader{{Name: "Cache-Control", Value: "immutable"}, {Name: "X-Asset", Value: "true"}}},
+		{Path: "/index.html", Headers: []StaticHeader{{Name: "Cache-Control", Value: "no-cache"}}},
+		{Path: "/*", Headers: []StaticHeader{{Name: "X-Frame-Options", Value: "DENY"}}},
+	}, server.Headers)
+
+	require.Len(t, server.Redirects, 1)
+	require.Equal(t, 301, server.Redirects[0].Status)
+
+	require.Len(t, server.Rewrites, 1)
+	require.Equal(t, "/index.html", server.Rewrites[0].To)
+
+	require.Equal(t, []StaticProxyRule{
+		{Directive: "handle", Path: "/graphql", Upstream: "{$API_URL}"},
+		{Directive: "handle_path", Path: "/api/*", Upstream: "https://api.example.com"},
+	}, server.Proxies)
+
+	require.True(t, server.Precompress)
+}
@@ -0,0 +1,51 @@
+package generate
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/railwayapp/railpack/core/config"
+	"github.com/railwayapp/railpack/internal/utils"
+)
+
+// GetSystemPackages returns the apt packages needed to build and run the dependencies that are used.
+// The systemPackages config for the ecosystem (e.g. node) is merged over the defaults.
+func (c *GenerateContext) GetSystemPackages(ecosystem string, defaults map[string]config.SystemPackages, usesDep func(dep string) bool) config.SystemPackages {
+	mapping := maps.Clone(defaults)
+	for dep, packages := range c.Config.SystemPackages[ecosystem] {
+		if packages != nil {
+			mapping[dep] = *packages
+		}
+	}
+
+	result := config.SystemPackages{}
+	for _, dep := range slices.Sorted(maps.Keys(mapping)) {
+		packages := mapping[dep]
+		if len(packages.Build) == 0 && len(packages.Runtime) == 0 {
+			continue
+		}
+
+		if !usesDep(dep) {
+			continue
+		}
+
+		c.Logger.LogInfo("Installing apt packages for %s", dep)
+		result.Build = append(result.Build, packages.Build...)
+		result.Runtime = append(result.Runtime, packages.Runtime...)
+	}
+
+	result.Build = utils.RemoveDuplicates(result.Build)
+	result.Runtime = utils.RemoveDuplicates(result.Runtime)
+
+	return result
+}
+
+// AddSystemPackages installs the build packages of the used dependencies in the mise step
+// and the runtime packages in the final image
+func (c *GenerateContext) AddSystemPackages(ecosystem string, defaults map[string]config.SystemPackages, usesDep func(dep string) bool) {
+	packages := c.GetSystemPackages(ecosystem, defaults, usesDep)
+
+	miseStep := c.GetMiseStepBuilder()
+	miseStep.SupportingAptPackages = append(miseStep.SupportingAptPackages, packages.Build...)
+	c.Deploy.AddAptPackages(packages.Runtime)
+}
@@ -0,0 +1,42 @@
+package generate
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/railwayapp/railpack/core/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemPackages(t *testing.T) {
+	ctx := CreateTestContext(t, "../../examples/node-npm")
+	ctx.Config.SystemPackages = map[string]map[string]*config.SystemPackages{
+		"node": {
+			"sharp":  {Build: []string{"libvips-dev"}, Runtime: []string{"libvips42"}},
+			"canvas": {},
+		},
+	}
+
+	defaults := map[string]config.SystemPackages{
+		"canvas":    {Build: []string{"libcairo2-dev"}, Runtime: []string{"libcairo2"}},
+		"bcrypt":    {Build: []string{"python3"}},
+		"puppeteer": {Runtime: []string{"libnss3"}},
+		"unused":    {Runtime: []string{"ffmpeg"}},
+	}
+
+	used := []string{"canvas", "bcrypt", "puppeteer", "sharp"}
+	usesDep := func(dep string) bool { return slices.Contains(used, dep) }
+
+	packages := ctx.GetSystemPackages("node", defaults, usesDep)
+	require.Equal(t, []string{"python3", "libvips-dev"}, packages.Build)
+	require.Equal(t, []string{"libnss3", "libvips42"}, packages.Runtime)
+
+	// The config of other ecosystems is not used
+	packages = ctx.GetSystemPackages("python", defaults, usesDep)
+	require.Equal(t, []string{"python3", "libcairo2-dev"}, packages.Build)
+	require.Equal(t, []string{"libcairo2", "libnss3"}, packages.Runtime)
+
+	ctx.AddSystemPackages("node", defaults, usesDep)
+	require.Subset(t, ctx.GetMiseStepBuilder().SupportingAptPackages, []string{"python3", "libvips-dev"})
+	require.Equal(t, []string{"libnss3", "libvips42"}, ctx.Deploy.AptPackages)
+}
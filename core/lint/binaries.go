@@ -0,0 +1,240 @@
+package lint
+
+import (
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/app"
+)
+
+// installCommand is a command that installs the packages given as arguments into a directory on the PATH
+type installCommand struct {
+	prefix []string
+
+	// The packages are only installed globally with -g or --global
+	global bool
+}
+
+var installCommands = []installCommand{
+	{prefix: []string{"npm", "install"}, global: true},
+	{prefix: []string{"npm", "i"}, global: true},
+	{prefix: []string{"pnpm", "add"}, global: true},
+	{prefix: []string{"bun", "add"}, global: true},
+	{prefix: []string{"bun", "install"}, global: true},
+	{prefix: []string{"yarn", "global", "add"}},
+	{prefix: []string{"pip", "install"}},
+	{prefix: []string{"pip3", "install"}},
+	{prefix: []string{"pipx", "install"}},
+	{prefix: []string{"uv", "tool", "install"}},
+	{prefix: []string{"go", "install"}},
+	{prefix: []string{"cargo", "install"}},
+	{prefix: []string{"gem", "install"}},
+	{prefix: []string{"apt-get", "install"}},
+	{prefix: []string{"apt", "install"}},
+}
+
+// corepackBinaries are the package managers corepack enable links when no package managers are given
+var corepackBinaries = []string{"pnpm", "pnpx", "yarn", "yarnpkg"}
+
+var (
+	requirementNameRegex = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+	gemfileGemRegex      = regexp.MustCompile(`(?m)^\s*gem\s+["']([^"']+)["']`)
+)
+
+// installedBinaries returns the packages installed by a command, e.g. mise install-into caddy@2.9.1 /railpack/caddy
+// npm install -g serve, apt-get install -y neofetch, or corepack enable. Each part of a compound command is checked.
+func installedBinaries(command string) []string {
+	replacer := strings.NewReplacer("&&", "\n", "||", "\n", ";", "\n", "|", "\n")
+
+	binaries := []string{}
+	for _, part := range strings.Split(replacer.Replace(command), "\n") {
+		words := strings.Fields(strings.NewReplacer(`"`, "", "'", "").Replace(part))
+
+		// The second argument of install-into is the directory the tool is installed into
+		if len(words) > 2 && words[0] == "mise" && words[1] == "install-into" {
+			binaries = append(binaries, packageName(words[2]))
+			continue
+		}
+
+		if len(words) > 1 && words[0] == "corepack" && words[1] == "enable" {
+			names := slices.DeleteFunc(slices.Clone(words[2:]), func(arg string) bool { return strings.HasPrefix(arg, "-") })
+			if len(names) == 0 {
+				names = corepackBinaries
+			}
+			binaries = append(binaries, names...)
+			continue
+		}
+
+		for _, install := range installCommands {
+			if len(words) <= len(install.prefix) || !slices.Equal(words[:len(install.prefix)], install.prefix) {
+				continue
+			}
+
+			args := words[len(install.prefix):]
+			if install.global && !slices.Contains(args, "-g") && !slices.Contains(args, "--global") {
+				continue
+			}
+
+			for _, arg := range args {
+				if !strings.HasPrefix(arg, "-") {
+					binaries = append(binaries, packageName(arg))
+				}
+			}
+		}
+	}
+
+	return binaries
+}
+
+// packageName returns the name of a package without its version, backend, or module path,
+// e.g. serve@14, ubi:caddyserver/caddy, gunicorn==23.0, or github.com/air-verse/air@latest
+func packageName(pkg string) string {
+	if i := strings.LastIndex(pkg, "@"); i > 0 {
+		pkg = pkg[:i]
+	}
+	if i := strings.IndexAny(pkg, "=<>~![;"); i > 0 {
+		pkg = pkg[:i]
+	}
+	if _, name, ok := strings.Cut(pkg, ":"); ok {
+		pkg = name
+	}
+
+	return path.Base(pkg)
+}
+
+// dependencyBinaries returns the names of the dependencies declared by the manifests of the app,
+// and the binaries the app declares itself (e.g. package.json bin or pyproject.toml scripts)
+func dependencyBinaries(a *app.App) []string {
+	names := []string{}
+
+	var packageJson struct {
+		Name            string            `json:"name"`
+		Bin             any               `json:"bin"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if a.ReadJSON("package.json", &packageJson) == nil {
+		names = append(names, slices.Collect(maps.Keys(packageJson.Dependencies))...)
+		names = append(names, slices.Collect(maps.Keys(packageJson.DevDependencies))...)
+
+		switch bin := packageJson.Bin.(type) {
+		case string:
+			names = append(names, path.Base(packageJson.Name))
+		case map[string]any:
+			names = append(names, slices.Collect(maps.Keys(bin))...)
+		}
+	}
+
+	if requirements, err := a.ReadFile("requirements.txt"); err == nil {
+		for _, line := range strings.Split(requirements, "\n") {
+			if match := requirementNameRegex.FindStringSubmatch(line); match != nil {
+				names = append(names, match[1])
+			}
+		}
+	}
+
+	var pyproject struct {
+		Project struct {
+			Dependencies []string          `toml:"dependencies"`
+			Scripts      map[string]string `toml:"scripts"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Dependencies map[string]any    `toml:"dependencies"`
+				Scripts      map[string]string `toml:"scripts"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if a.ReadTOML("pyproject.toml", &pyproject) == nil {
+		for _, dep := range pyproject.Project.Dependencies {
+			if match := requirementNameRegex.FindStringSubmatch(dep); match != nil {
+				names = append(names, match[1])
+			}
+		}
+		names = append(names, slices.Collect(maps.Keys(pyproject.Project.Scripts))...)
+		names = append(names, slices.Collect(maps.Keys(pyproject.Tool.Poetry.Dependencies))...)
+		names = append(names, slices.Collect(maps.Keys(pyproject.Tool.Poetry.Scripts))...)
+	}
+
+	var pipfile struct {
+		Packages    map[string]any `toml:"packages"`
+		DevPackages map[string]any `toml:"dev-packages"`
+	}
+	if a.ReadTOML("Pipfile", &pipfile) == nil {
+		names = append(names, slices.Collect(maps.Keys(pipfile.Packages))...)
+		names = append(names, slices.Collect(maps.Keys(pipfile.DevPackages))...)
+	}
+
+	var pixi struct {
+		Dependencies map[string]any `toml:"dependencies"`
+	}
+	if a.ReadTOML("pixi.toml", &pixi) == nil {
+		names = append(names, slices.Collect(maps.Keys(pixi.Dependencies))...)
+	}
+
+	var environment struct {
+		Dependencies []any `yaml:"dependencies"`
+	}
+	if a.ReadYAML("environment.yml", &environment) == nil {
+		for _, dep := range environment.Dependencies {
+			if spec, ok := dep.(string); ok {
+				if match := requirementNameRegex.FindStringSubmatch(spec); match != nil {
+					names = append(names, match[1])
+				}
+			}
+		}
+	}
+
+	if gemfile, err := a.ReadFile("Gemfile"); err == nil {
+		for _, match := range gemfileGemRegex.FindAllStringSubmatch(gemfile, -1) {
+			names = append(names, match[1])
+		}
+	}
+
+	var composer struct {
+		Require    map[string]string `json:"require"`
+		RequireDev map[string]string `json:"require-dev"`
+		Bin        any               `json:"bin"`
+	}
+	if a.ReadJSON("composer.json", &composer) == nil {
+		for _, name := range append(slices.Collect(maps.Keys(composer.Require)), slices.Collect(maps.Keys(composer.RequireDev))...) {
+			names = append(names, path.Base(name))
+		}
+		switch bin := composer.Bin.(type) {
+		case string:
+			names = append(names, path.Base(bin))
+		case []any:
+			for _, b := range bin {
+				if b, ok := b.(string); ok {
+					names = append(names, path.Base(b))
+				}
+			}
+		}
+	}
+
+	var cargo struct {
+		Package struct {
+			Name string `toml:"name"`
+		} `toml:"package"`
+		Bin []struct {
+			Name string `toml:"name"`
+		} `toml:"bin"`
+	}
+	if a.ReadTOML("Cargo.toml", &cargo) == nil {
+		names = append(names, cargo.Package.Name)
+		for _, bin := range cargo.Bin {
+			names = append(names, bin.Name)
+		}
+	}
+
+	return names
+}
+
+// sameBinary compares binary names the way package managers normalize them (e.g. Django_Extensions and django-extensions)
+func sameBinary(a, b string) bool {
+	normalize := strings.NewReplacer("_", "-", ".", "-")
+	return strings.EqualFold(normalize.Replace(a), normalize.Replace(b))
+}
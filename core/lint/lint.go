@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/app"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+// Context is everything a rule can inspect
+type Context struct {
+	Plan *plan.BuildPlan
+
+	// The app source. This is nil when linting a saved plan.
+	App *app.App
+
+	// Steps that deploy does not depend on. These are not part of a normalized plan,
+	// so they must be collected before normalization.
+	UnreachableSteps []string
+}
+
+// Finding is a single problem reported by a rule
+type Finding struct {
+	RuleID string `json:"ruleId"`
+
+	// Dotted path to the part of the plan the finding is about (e.g. "deploy.inputs[1]")
+	Path string `json:"path,omitempty"`
+
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	if f.Path == "" {
+		return fmt.Sprintf("%s: %s", f.RuleID, f.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", f.RuleID, f.Path, f.Message)
+}
+
+type Rule interface {
+	// Stable identifier of the rule (e.g. RP001). IDs are never reused.
+	ID() string
+	Name() string
+	Description() string
+	Check(ctx *Context) []Finding
+}
+
+func GetRules() []Rule {
+	return []Rule{
+		&DeployCopiesGitRule{},
+		&CacheInDeployRule{},
+		&UnusedSecretRule{},
+		&UnreachableStepRule{},
+		&StartCommandNotOnPathRule{},
+		&StartCommandNeedsShellRule{},
+	}
+}
+
+func GetRule(id string) Rule {
+	for _, rule := range GetRules() {
+		if rule.ID() == id {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+// Lint runs every rule against the plan and returns the findings ordered by rule ID
+func Lint(ctx *Context) []Finding {
+	findings := []Finding{}
+	if ctx == nil || ctx.Plan == nil {
+		return findings
+	}
+
+	rules := GetRules()
+	slices.SortFunc(rules, func(a, b Rule) int { return strings.Compare(a.ID(), b.ID()) })
+
+	for _, rule := range rules {
+		findings = append(findings, rule.Check(ctx)...)
+	}
+
+	return findings
+}
@@ -0,0 +1,251 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/railwayapp/railpack/core/app"
+	"github.com/railwayapp/railpack/core/plan"
+	testingUtils "github.com/railwayapp/railpack/core/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func nodePlan() *plan.BuildPlan {
+	return &plan.BuildPlan{
+		Steps: []plan.Step{
+			{
+				Name:   "packages:mise",
+				Inputs: []plan.Layer{plan.NewImageLayer(plan.RailpackBuilderImage)},
+				Commands: []plan.Command{
+					plan.NewPathCommand("/mise/shims"),
+				},
+				Assets: map[string]string{
+					"mise.toml": "[tools]\nnode = \"22.11.0\"\n",
+				},
+			},
+			{
+				Name:    "build",
+				Inputs:  []plan.Layer{plan.NewStepLayer("packages:mise"), plan.NewLocalLayer()},
+				Caches:  []string{"node-modules"},
+				Secrets: []string{"NPM_TOKEN"},
+			},
+		},
+		Caches: map[string]*plan.Cache{
+			"node-modules": plan.NewCache("/app/node_modules/.cache"),
+		},
+		Secrets: []string{"NPM_TOKEN"},
+		Deploy: plan.Deploy{
+			Base: plan.NewImageLayer(plan.RailpackRuntimeImage),
+			Inputs: []plan.Layer{
+				plan.NewStepLayer("packages:mise", plan.NewIncludeFilter([]string{"/mise/shims", "/mise/installs"})),
+				plan.NewStepLayer("build", plan.NewFilter([]string{"."}, []string{".git"})),
+			},
+			StartCmd: "npm run start",
+		},
+	}
+}
+
+func ruleIDs(findings []Finding) []string {
+	ids := []string{}
+	for _, finding := range findings {
+		ids = append(ids, finding.RuleID)
+	}
+	return ids
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(p *plan.BuildPlan)
+		expected []string
+	}{
+		{
+			name:     "no findings",
+			modify:   func(p *plan.BuildPlan) {},
+			expected: []string{},
+		},
+		{
+			name: "deploy copies git",
+			modify: func(p *plan.BuildPlan) {
+				p.Deploy.Inputs[1] = plan.NewStepLayer("build", plan.NewIncludeFilter([]string{"."}))
+			},
+			expected: []string{"RP001"},
+		},
+		{
+			name: "deploy copies app without local source",
+			modify: func(p *plan.BuildPlan) {
+				p.Steps[1].Inputs = []plan.Layer{plan.NewStepLayer("packages:mise")}
+				p.Deploy.Inputs[1] = plan.NewStepLayer("build", plan.NewIncludeFilter([]string{"."}))
+			},
+			expected: []string{},
+		},
+		{
+			name: "cache in deploy",
+			modify: func(p *plan.BuildPlan) {
+				p.Deploy.Inputs = append(p.Deploy.Inputs, plan.NewStepLayer("build", plan.NewIncludeFilter([]string{"node_modules/.cache/vite"})))
+			},
+			expected: []string{"RP002"},
+		},
+		{
+			name: "unused secret",
+			modify: func(p *plan.BuildPlan) {
+				p.Secrets = append(p.Secrets, "DATABASE_URL")
+			},
+			expected: []string{"RP003"},
+		},
+		{
+			name: "secrets used by wildcard",
+			modify: func(p *plan.BuildPlan) {
+				p.Steps[1].Secrets = []string{"*"}
+				p.Secrets = append(p.Secrets, "DATABASE_URL")
+			},
+			expected: []string{},
+		},
+		{
+			name: "start command not on path",
+			modify: func(p *plan.BuildPlan) {
+				p.Deploy.Inputs = p.Deploy.Inputs[:0]
+				p.Deploy.StartCmd = "PORT=3000 server && echo done"
+			},
+			expected: []string{"RP005"},
+		},
+		{
+			name: "start command not installed by mise",
+			modify: func(p *plan.BuildPlan) {
+				p.Deploy.StartCmd = "serve dist"
+			},
+			expected: []string{"RP005"},
+		},
+		{
+			name: "start command installed by a deployed step",
+			modify: func(p *plan.BuildPlan) {
+				p.Steps[1].Commands = []plan.Command{plan.NewExecCommand("npm install -g serve@14")}
+				p.Deploy.StartCmd = "serve dist"
+			},
+			expected: []string{},
+		},
+		{
+			name: "start command only used by a deployed step",
+			modify: func(p *plan.BuildPlan) {
+				p.Steps[1].Commands = []plan.Command{plan.NewExecCommand("npx serve --version")}
+				p.Deploy.StartCmd = "serve dist"
+			},
+			expected: []string{"RP005"},
+		},
+		{
+			name: "start command uses a path",
+			modify: func(p *plan.BuildPlan) {
+				p.Deploy.Inputs = p.Deploy.Inputs[:0]
+				p.Deploy.StartCmd = "./out"
+			},
+			expected: []string{},
+		},
+		{
+			name: "start command needs shell",
+			modify: func(p *plan.BuildPlan) {
+				p.Deploy.NoShell = true
+				p.Deploy.StartCmd = "./out --port $PORT"
+			},
+			expected: []string{"RP006"},
+		},
+		{
+			name: "start command without shell",
+			modify: func(p *plan.BuildPlan) {
+				p.Deploy.NoShell = true
+				p.Deploy.StartCmd = "./out --verbose"
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := nodePlan()
+			tt.modify(p)
+
+			findings := Lint(&Context{Plan: p})
+			require.Equal(t, tt.expected, ruleIDs(findings))
+		})
+	}
+}
+
+func TestLintStartCommandFromDependencies(t *testing.T) {
+	lintStartCommand := func(t *testing.T, packageJson string) []string {
+		a, err := app.NewApp(testingUtils.CreateAppDir(t, map[string]string{"package.json": packageJson}))
+		require.NoError(t, err)
+
+		p := nodePlan()
+		p.Deploy.Paths = []string{"/app/node_modules/.bin"}
+		p.Deploy.StartCmd = "serve dist"
+		return ruleIDs(Lint(&Context{Plan: p, App: a}))
+	}
+
+	require.Empty(t, lintStartCommand(t, `{"dependencies": {"serve": "14"}}`))
+	require.Empty(t, lintStartCommand(t, `{"name": "serve", "bin": "cli.js"}`))
+	require.Empty(t, lintStartCommand(t, `{"bin": {"serve": "cli.js"}}`))
+
+	// The binary name only appears in other packages and the scripts
+	require.Equal(t, []string{"RP005"}, lintStartCommand(t, `{
+		"description": "Apps that serve static files",
+		"scripts": {"start": "serve dist"},
+		"dependencies": {"@fastify/serve-static": "8"}
+	}`))
+}
+
+func TestInstalledBinaries(t *testing.T) {
+	require.Equal(t, []string{"caddy"}, installedBinaries("mise install-into caddy@2.9.1 /railpack/caddy"))
+	require.Equal(t, []string{"serve", "cli"}, installedBinaries("npm install -g serve@14 @nestjs/cli"))
+	require.Equal(t, []string{"air"}, installedBinaries("go mod download && go install github.com/air-verse/air@latest"))
+	require.Equal(t, []string{"gunicorn"}, installedBinaries("pip install gunicorn==23.0"))
+	require.Equal(t, []string{"neofetch"}, installedBinaries("sh -c 'apt-get update && apt-get install -y neofetch'"))
+	require.Equal(t, append([]string{"corepack"}, corepackBinaries...), installedBinaries("npm i -g corepack@latest && corepack enable && corepack prepare --activate"))
+	require.Equal(t, []string{"pnpm"}, installedBinaries("corepack enable pnpm"))
+	require.Empty(t, installedBinaries("npm install serve"))
+	require.Empty(t, installedBinaries("npx serve --version"))
+}
+
+func TestLintUnreachableSteps(t *testing.T) {
+	findings := Lint(&Context{Plan: nodePlan(), UnreachableSteps: []string{"test"}})
+	require.Len(t, findings, 1)
+	require.Equal(t, "RP004", findings[0].RuleID)
+	require.Equal(t, "steps.test", findings[0].Path)
+}
+
+func TestLintSkipsGitRuleWithoutGitDirectory(t *testing.T) {
+	dir := t.TempDir()
+	a, err := app.NewApp(dir)
+	require.NoError(t, err)
+
+	p := nodePlan()
+	p.Deploy.Inputs[1] = plan.NewStepLayer("build", plan.NewIncludeFilter([]string{"."}))
+
+	require.Empty(t, Lint(&Context{Plan: p, App: a}))
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0755))
+	require.Equal(t, []string{"RP001"}, ruleIDs(Lint(&Context{Plan: p, App: a})))
+}
+
+func TestRuleIDsAreUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, rule := range GetRules() {
+		require.False(t, seen[rule.ID()], "duplicate rule ID %s", rule.ID())
+		seen[rule.ID()] = true
+		require.Equal(t, rule, GetRule(rule.ID()))
+	}
+}
+
+func TestToSarif(t *testing.T) {
+	findings := []Finding{{RuleID: "RP003", Path: "secrets", Message: "secret `A` is not used by any step"}}
+
+	log := ToSarif(findings, "1.0.0", "railpack.json")
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	require.Len(t, log.Runs[0].Tool.Driver.Rules, len(GetRules()))
+
+	result := log.Runs[0].Results[0]
+	require.Equal(t, "RP003", result.RuleID)
+	require.Equal(t, "warning", result.Level)
+	require.Equal(t, "railpack.json", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, "secrets", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+}
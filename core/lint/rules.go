@@ -0,0 +1,530 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+const (
+	appDir = "/app"
+)
+
+// DeployCopiesGitRule reports deploy inputs that copy the entire app directory,
+// including .git, from a step that loaded the local source
+type DeployCopiesGitRule struct{}
+
+func (r *DeployCopiesGitRule) ID() string   { return "RP001" }
+func (r *DeployCopiesGitRule) Name() string { return "deploy-copies-git" }
+func (r *DeployCopiesGitRule) Description() string {
+	return "A deploy input copies all of /app, including the .git directory"
+}
+
+func (r *DeployCopiesGitRule) Check(ctx *Context) []Finding {
+	if ctx.App != nil && !ctx.App.HasFile(".git") {
+		return nil
+	}
+
+	steps := stepsByName(ctx.Plan)
+	findings := []Finding{}
+
+	for i, input := range ctx.Plan.Deploy.Inputs {
+		if !includesAppDir(input.Include) || excludesGit(input.Exclude) {
+			continue
+		}
+
+		source := "the local source"
+		if input.Step != "" {
+			if !copiesLocalSource(steps, input.Step, map[string]bool{}) {
+				continue
+			}
+			source = fmt.Sprintf("step `%s`", input.Step)
+		} else if !input.Local {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			RuleID:  r.ID(),
+			Path:    fmt.Sprintf("deploy.inputs[%d]", i),
+			Message: fmt.Sprintf("copies all of %s from %s, including the .git directory. Add `.git` to the input's exclude list", appDir, source),
+		})
+	}
+
+	return findings
+}
+
+// CacheInDeployRule reports deploy inputs that include a cache directory.
+// Caches are mounted while a step runs, so their contents are never part of the step output.
+type CacheInDeployRule struct{}
+
+func (r *CacheInDeployRule) ID() string   { return "RP002" }
+func (r *CacheInDeployRule) Name() string { return "cache-in-deploy" }
+func (r *CacheInDeployRule) Description() string {
+	return "A cache directory is also included in a deploy layer"
+}
+
+func (r *CacheInDeployRule) Check(ctx *Context) []Finding {
+	steps := stepsByName(ctx.Plan)
+	findings := []Finding{}
+
+	for i, input := range ctx.Plan.Deploy.Inputs {
+		if input.Step == "" {
+			continue
+		}
+
+		cacheNames := []string{}
+		for name := range dependencies(steps, input.Step) {
+			cacheNames = append(cacheNames, steps[name].Caches...)
+		}
+		slices.Sort(cacheNames)
+		cacheNames = slices.Compact(cacheNames)
+
+		for _, include := range input.Include {
+			for _, cacheName := range cacheNames {
+				cache := ctx.Plan.Caches[cacheName]
+				if cache == nil || cache.Directory == "" {
+					continue
+				}
+
+				if isWithin(absolutePath(include), absolutePath(cache.Directory)) {
+					findings = append(findings, Finding{
+						RuleID:  r.ID(),
+						Path:    fmt.Sprintf("deploy.inputs[%d]", i),
+						Message: fmt.Sprintf("includes %s which is in the `%s` cache (%s). Cache directories are not part of the step output, so nothing will be copied", include, cacheName, cache.Directory),
+					})
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+// UnusedSecretRule reports secrets in the plan that no step has access to
+type UnusedSecretRule struct{}
+
+func (r *UnusedSecretRule) ID() string   { return "RP003" }
+func (r *UnusedSecretRule) Name() string { return "unused-secret" }
+func (r *UnusedSecretRule) Description() string {
+	return "A secret is referenced by the plan but never used by a step"
+}
+
+func (r *UnusedSecretRule) Check(ctx *Context) []Finding {
+	used := map[string]bool{}
+	for _, step := range ctx.Plan.Steps {
+		for _, secret := range step.Secrets {
+			if secret == "*" {
+				return nil
+			}
+			used[secret] = true
+		}
+	}
+
+	findings := []Finding{}
+	for _, secret := range ctx.Plan.Secrets {
+		if !used[secret] {
+			findings = append(findings, Finding{
+				RuleID:  r.ID(),
+				Path:    "secrets",
+				Message: fmt.Sprintf("secret `%s` is not used by any step", secret),
+			})
+		}
+	}
+
+	return findings
+}
+
+// UnreachableStepRule reports steps that are removed from the plan because deploy does not depend on them
+type UnreachableStepRule struct{}
+
+func (r *UnreachableStepRule) ID() string   { return "RP004" }
+func (r *UnreachableStepRule) Name() string { return "unreachable-step" }
+func (r *UnreachableStepRule) Description() string {
+	return "A step is not reachable from deploy and is removed from the plan"
+}
+
+func (r *UnreachableStepRule) Check(ctx *Context) []Finding {
+	findings := []Finding{}
+	for _, name := range ctx.UnreachableSteps {
+		findings = append(findings, Finding{
+			RuleID:  r.ID(),
+			Path:    "steps." + name,
+			Message: fmt.Sprintf("step `%s` is not used by deploy or by any step deploy depends on, so it will not run. Add it to the deploy inputs or to another step's inputs", name),
+		})
+	}
+
+	return findings
+}
+
+// StartCommandNotOnPathRule reports start commands that run a binary that cannot be found in the deployed image.
+// Only plans deployed on the railpack runtime image are checked, since the contents of other base images are unknown.
+type StartCommandNotOnPathRule struct{}
+
+func (r *StartCommandNotOnPathRule) ID() string   { return "RP005" }
+func (r *StartCommandNotOnPathRule) Name() string { return "start-command-not-on-path" }
+func (r *StartCommandNotOnPathRule) Description() string {
+	return "The start command references a binary that is not on the deploy PATH"
+}
+
+// Shell builtins and binaries that are available in the railpack runtime image
+var runtimeImageBinaries = []string{
+	".", "[", "awk", "basename", "bash", "cat", "cd", "chmod", "cp", "cut", "dirname", "echo", "env", "eval",
+	"exec", "exit", "export", "find", "grep", "head", "ln", "ls", "mkdir", "mv", "printf", "rm", "sed", "set",
+	"sh", "sleep", "sort", "source", "tail", "test", "touch", "tr", "true", "wc", "xargs",
+}
+
+// Binaries installed by mise tools whose names differ from the tool
+var miseToolBinaries = map[string][]string{
+	"node":   {"node", "npm", "npx", "corepack"},
+	"python": {"python", "python3", "pip", "pip3"},
+	"bun":    {"bun", "bunx"},
+	"go":     {"go", "gofmt"},
+	"ruby":   {"ruby", "gem", "bundle", "bundler", "rake", "irb"},
+	"java":   {"java", "javac", "jar"},
+	"maven":  {"mvn"},
+	"rust":   {"cargo", "rustc"},
+	"elixir": {"elixir", "mix", "iex"},
+	"erlang": {"erl", "erlc", "escript"},
+	"uv":     {"uv", "uvx"},
+}
+
+const miseShimsDir = "/mise/shims"
+
+func (r *StartCommandNotOnPathRule) Check(ctx *Context) []Finding {
+	deploy := ctx.Plan.Deploy
+	if deploy.StartCmd == "" || stripDigest(baseImage(ctx.Plan)) != plan.RailpackRuntimeImage {
+		return nil
+	}
+
+	findings := []Finding{}
+	for _, binary := range commandBinaries(deploy.StartCmd) {
+		if slices.Contains(runtimeImageBinaries, binary) || providesBinary(ctx, binary) {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			RuleID:  r.ID(),
+			Path:    "deploy.startCommand",
+			Message: fmt.Sprintf("`%s` is not on the PATH of the deployed image. Use a path to the binary or add its directory to deploy.paths", binary),
+		})
+	}
+
+	return findings
+}
+
+// providesBinary checks if a binary is installed in a deployed PATH directory by a mise package,
+// by a step that deploy depends on, or as a dependency of the app
+func providesBinary(ctx *Context, binary string) bool {
+	dirs := deployedPathDirs(ctx.Plan)
+	if len(dirs) == 0 {
+		return false
+	}
+
+	if slices.Contains(dirs, miseShimsDir) && slices.Contains(miseBinaries(ctx.Plan), binary) {
+		return true
+	}
+
+	if stepsInstallBinary(ctx.Plan, binary) {
+		return true
+	}
+
+	otherDirs := slices.DeleteFunc(slices.Clone(dirs), func(dir string) bool { return dir == miseShimsDir })
+	if len(otherDirs) == 0 {
+		return false
+	}
+
+	// Without the app source the contents of dependency directories are unknown
+	if ctx.App == nil {
+		return true
+	}
+
+	for _, dir := range otherDirs {
+		if rel, ok := strings.CutPrefix(absolutePath(dir), appDir+"/"); ok && ctx.App.HasFile(path.Join(rel, binary)) {
+			return true
+		}
+	}
+
+	// Dependencies are installed into the dependency bin directories (e.g. node_modules/.bin or .venv/bin)
+	return slices.ContainsFunc(dependencyBinaries(ctx.App), func(name string) bool {
+		return sameBinary(name, binary)
+	})
+}
+
+// miseBinaries returns the binaries of the tools in the mise config of the step that adds the mise shims to the PATH
+func miseBinaries(p *plan.BuildPlan) []string {
+	binaries := []string{}
+	for _, step := range p.Steps {
+		if !slices.ContainsFunc(step.Commands, func(cmd plan.Command) bool {
+			pathCmd, ok := cmd.(plan.PathCommand)
+			return ok && pathCmd.Path == miseShimsDir
+		}) {
+			continue
+		}
+
+		var config struct {
+			Tools map[string]any `toml:"tools"`
+		}
+		if _, err := toml.Decode(step.Assets["mise.toml"], &config); err != nil {
+			continue
+		}
+
+		for tool := range config.Tools {
+			// Tools from other backends are named after the package (e.g. pipx:uv or go:github.com/go-delve/delve/cmd/dlv)
+			if _, name, ok := strings.Cut(tool, ":"); ok {
+				tool = path.Base(name)
+			}
+
+			binaries = append(binaries, tool)
+			binaries = append(binaries, miseToolBinaries[tool]...)
+		}
+	}
+
+	return binaries
+}
+
+// stepsInstallBinary checks if a command of a step that deploy depends on installs the binary,
+// e.g. mise install-into caddy@2.9.1 /railpack/caddy or npm install -g serve
+func stepsInstallBinary(p *plan.BuildPlan, binary string) bool {
+	steps := stepsByName(p)
+
+	deployed := map[string]bool{}
+	for _, input := range append([]plan.Layer{p.Deploy.Base}, p.Deploy.Inputs...) {
+		if input.Step != "" {
+			maps.Copy(deployed, dependencies(steps, input.Step))
+		}
+	}
+
+	for name := range deployed {
+		for _, cmd := range steps[name].Commands {
+			execCmd, ok := cmd.(plan.ExecCommand)
+			if !ok {
+				continue
+			}
+
+			if slices.ContainsFunc(installedBinaries(execCmd.Cmd), func(name string) bool {
+				return sameBinary(name, binary)
+			}) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// baseImage returns the image the deploy base is built from, following step bases to their image
+func baseImage(p *plan.BuildPlan) string {
+	steps := stepsByName(p)
+	base := p.Deploy.Base
+
+	visited := map[string]bool{}
+	for base.Image == "" && base.Step != "" && !visited[base.Step] {
+		visited[base.Step] = true
+
+		step, ok := steps[base.Step]
+		if !ok || len(step.Inputs) == 0 {
+			return ""
+		}
+		base = step.Inputs[0]
+	}
+
+	return base.Image
+}
+
+// StartCommandNeedsShellRule reports start commands that use shell syntax when the deployed image does not have a shell
+type StartCommandNeedsShellRule struct{}
+
+func (r *StartCommandNeedsShellRule) ID() string   { return "RP006" }
+func (r *StartCommandNeedsShellRule) Name() string { return "start-command-needs-shell" }
+func (r *StartCommandNeedsShellRule) Description() string {
+	return "The start command uses shell syntax but the deployed image has no shell"
+}
+
+func (r *StartCommandNeedsShellRule) Check(ctx *Context) []Finding {
+	deploy := ctx.Plan.Deploy
+	if !deploy.NoShell || deploy.StartCmd == "" {
+		return nil
+	}
+
+	if !plan.CommandNeedsShell(deploy.StartCmd) {
+		return nil
+	}
+
+	return []Finding{{
+		RuleID:  r.ID(),
+		Path:    "deploy.startCommand",
+		Message: "uses shell syntax, but the start command is run without a shell. Run the binary directly or deploy on an image with a shell",
+	}}
+}
+
+// deployedPathDirs returns the PATH directories from deploy.paths and path commands
+// that are included in the deployed image
+func deployedPathDirs(p *plan.BuildPlan) []string {
+	dirs := []string{}
+	for _, dir := range p.Deploy.Paths {
+		if isDeployed(p, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, step := range p.Steps {
+		for _, cmd := range step.Commands {
+			if pathCmd, ok := cmd.(plan.PathCommand); ok && isDeployed(p, pathCmd.Path) {
+				dirs = append(dirs, pathCmd.Path)
+			}
+		}
+	}
+
+	return dirs
+}
+
+// isDeployed checks if a directory is copied into the deployed image by one of the deploy inputs
+func isDeployed(p *plan.BuildPlan, dir string) bool {
+	dir = absolutePath(dir)
+
+	for _, input := range p.Deploy.Inputs {
+		if input.Step == "" && !input.Local {
+			continue
+		}
+
+		if len(input.Include) == 0 {
+			return true
+		}
+
+		for _, include := range input.Include {
+			include = absolutePath(include)
+			if isWithin(dir, include) || isWithin(include, dir) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// commandBinaries returns the binary run by each part of a shell command.
+// Parts that run a path or an expansion are skipped.
+func commandBinaries(command string) []string {
+	replacer := strings.NewReplacer("&&", "\n", "||", "\n", ";", "\n", "|", "\n")
+
+	binaries := []string{}
+	for _, part := range strings.Split(replacer.Replace(command), "\n") {
+		for _, word := range strings.Fields(part) {
+			// Skip leading environment variable assignments (e.g. RACK_ENV=production)
+			if strings.Contains(word, "=") {
+				continue
+			}
+
+			if !strings.ContainsAny(word, "/$`(") {
+				binaries = append(binaries, word)
+			}
+			break
+		}
+	}
+
+	return binaries
+}
+
+func stepsByName(p *plan.BuildPlan) map[string]*plan.Step {
+	steps := make(map[string]*plan.Step, len(p.Steps))
+	for i := range p.Steps {
+		steps[p.Steps[i].Name] = &p.Steps[i]
+	}
+	return steps
+}
+
+// dependencies returns the given step and every step it transitively depends on
+func dependencies(steps map[string]*plan.Step, name string) map[string]bool {
+	result := map[string]bool{}
+
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		step, ok := steps[current]
+		if !ok || result[current] {
+			continue
+		}
+		result[current] = true
+
+		for _, input := range step.Inputs {
+			if input.Step != "" {
+				queue = append(queue, input.Step)
+			}
+		}
+	}
+
+	return result
+}
+
+// copiesLocalSource checks if the app directory of a step contains the unfiltered local source
+func copiesLocalSource(steps map[string]*plan.Step, name string, visited map[string]bool) bool {
+	step, ok := steps[name]
+	if !ok || visited[name] {
+		return false
+	}
+	visited[name] = true
+
+	for _, input := range step.Inputs {
+		if !includesAppDir(input.Include) || excludesGit(input.Exclude) {
+			continue
+		}
+
+		if input.Local {
+			return true
+		}
+
+		if input.Step != "" && copiesLocalSource(steps, input.Step, visited) {
+			return true
+		}
+	}
+
+	for _, cmd := range step.Commands {
+		if copyCmd, ok := cmd.(plan.CopyCommand); ok && copyCmd.Image == "" && absolutePath(copyCmd.Src) == appDir {
+			return true
+		}
+	}
+
+	return false
+}
+
+// includesAppDir checks if a layer filter includes the app directory. Layers without includes copy everything.
+func includesAppDir(include []string) bool {
+	if len(include) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(include, func(p string) bool {
+		return isWithin(appDir, absolutePath(p))
+	})
+}
+
+func excludesGit(exclude []string) bool {
+	return slices.ContainsFunc(exclude, func(p string) bool {
+		return path.Base(path.Clean(p)) == ".git"
+	})
+}
+
+// absolutePath resolves a path relative to the app directory
+func absolutePath(p string) string {
+	if path.IsAbs(p) {
+		return path.Clean(p)
+	}
+	return path.Join(appDir, p)
+}
+
+// isWithin checks if p is dir or is inside of dir
+func isWithin(p, dir string) bool {
+	return p == dir || strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/")
+}
+
+func stripDigest(image string) string {
+	ref, _, _ := strings.Cut(image, "@")
+	return ref
+}
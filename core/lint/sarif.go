@@ -0,0 +1,112 @@
+package lint
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SarifLog is the subset of the SARIF 2.1.0 format used to report lint findings
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription SarifMessage `json:"shortDescription"`
+}
+
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations,omitempty"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation *SarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// ToSarif converts findings to a SARIF log.
+// If artifact is not empty, every result is located in that file (e.g. railpack.json or a saved plan).
+func ToSarif(findings []Finding, version string, artifact string) *SarifLog {
+	rules := []SarifRule{}
+	for _, rule := range GetRules() {
+		rules = append(rules, SarifRule{
+			ID:               rule.ID(),
+			Name:             rule.Name(),
+			ShortDescription: SarifMessage{Text: rule.Description()},
+		})
+	}
+
+	results := []SarifResult{}
+	for _, finding := range findings {
+		result := SarifResult{
+			RuleID:  finding.RuleID,
+			Level:   "warning",
+			Message: SarifMessage{Text: finding.Message},
+		}
+
+		if artifact != "" || finding.Path != "" {
+			location := SarifLocation{}
+			if artifact != "" {
+				location.PhysicalLocation = &SarifPhysicalLocation{ArtifactLocation: SarifArtifactLocation{URI: artifact}}
+			}
+			if finding.Path != "" {
+				location.LogicalLocations = []SarifLogicalLocation{{FullyQualifiedName: finding.Path}}
+			}
+			result.Locations = []SarifLocation{location}
+		}
+
+		results = append(results, result)
+	}
+
+	return &SarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []SarifRun{{
+			Tool: SarifTool{Driver: SarifDriver{
+				Name:           "railpack",
+				Version:        version,
+				InformationURI: "https://railpack.com",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
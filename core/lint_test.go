@@ -0,0 +1,37 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/railwayapp/railpack/core/app"
+	"github.com/railwayapp/railpack/core/lint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateBuildPlan_LintStartCommandNotOnPath(t *testing.T) {
+	lintStartCommand := func(t *testing.T, example string, startCmd string) []string {
+		userApp, err := app.NewApp("../examples/" + example)
+		require.NoError(t, err)
+
+		env := app.NewEnvironment(&map[string]string{"RAILPACK_START_CMD": startCmd})
+		buildResult := GenerateBuildPlan(userApp, env, &GenerateBuildPlanOptions{})
+		require.True(t, buildResult.Success)
+
+		messages := []string{}
+		for _, finding := range buildResult.Lint {
+			if finding.RuleID == (&lint.StartCommandNotOnPathRule{}).ID() {
+				messages = append(messages, finding.Message)
+			}
+		}
+		return messages
+	}
+
+	// Binaries from mise packages and the venv are on the PATH
+	require.Empty(t, lintStartCommand(t, "python-django", "gunicorn mysite.wsgi:application"))
+	require.Empty(t, lintStartCommand(t, "python-django", "python manage.py runserver"))
+	require.Empty(t, lintStartCommand(t, "node-npm", "npm run start"))
+
+	// The deployed PATH directories do not provide these binaries
+	require.Len(t, lintStartCommand(t, "python-django", "streamlit run app.py"), 1)
+	require.Len(t, lintStartCommand(t, "node-npm", "tsx index.ts"), 1)
+}
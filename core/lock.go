@@ -0,0 +1,155 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/app"
+	"github.com/railwayapp/railpack/core/logger"
+	"github.com/railwayapp/railpack/core/plan"
+	"github.com/railwayapp/railpack/core/registry"
+	"github.com/railwayapp/railpack/core/resolver"
+)
+
+const (
+	LockFileName = "railpack.lock"
+)
+
+// LockFile records the resolved package versions and image digests of a build
+// so that future builds are reproducible until the lock file is updated
+type LockFile struct {
+	RailpackVersion string                               `json:"railpackVersion,omitempty"`
+	Packages        map[string]*resolver.ResolvedPackage `json:"packages,omitempty"`
+
+	// Map of image reference (e.g. alpine:latest) to the digest it was resolved to
+	Images map[string]string `json:"images,omitempty"`
+}
+
+// ReadLockFile reads the lock file from the app source directory. Returns nil if there is no lock file.
+func ReadLockFile(app *app.App) (*LockFile, error) {
+	if !app.HasFile(LockFileName) {
+		return nil, nil
+	}
+
+	lockFile := &LockFile{}
+	if err := app.ReadJSON(LockFileName, lockFile); err != nil {
+		return nil, err
+	}
+
+	return lockFile, nil
+}
+
+// NewLockFile creates a lock file from a successful build result, resolving the digest of every image used by the plan
+func NewLockFile(buildResult *BuildResult) (*LockFile, error) {
+	if buildResult == nil || buildResult.Plan == nil {
+		return nil, fmt.Errorf("cannot create a lock file without a build plan")
+	}
+
+	lockFile := &LockFile{
+		RailpackVersion: buildResult.RailpackVersion,
+		Packages:        map[string]*resolver.ResolvedPackage{},
+		Images:          map[string]string{},
+	}
+
+	for name, pkg := range buildResult.ResolvedPackages {
+		lockedPkg := *pkg
+		lockedPkg.Locked = false
+		lockFile.Packages[name] = &lockedPkg
+	}
+
+	for _, image := range buildResult.Plan.GetImages() {
+		ref := registry.ParseReference(image)
+
+		// Images that are already pinned (e.g. from an existing lock file) keep their digest
+		if ref.Digest != "" {
+			lockFile.Images[stripDigest(image)] = ref.Digest
+			continue
+		}
+
+		digest, err := registry.ResolveDigest(image)
+		if err != nil {
+			return nil, err
+		}
+		lockFile.Images[image] = digest
+	}
+
+	return lockFile, nil
+}
+
+// Write writes the lock file to the given directory
+func (l *LockFile) Write(dir string) error {
+	serialized, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, LockFileName), append(serialized, '\n'), 0644)
+}
+
+// applyLockFile makes the resolver use the locked package versions
+func applyLockFile(lockFile *LockFile, r *resolver.Resolver) {
+	for _, name := range slices.Sorted(maps.Keys(lockFile.Packages)) {
+		r.SetLockedVersion(lockFile.Packages[name])
+	}
+}
+
+// warnStaleLockFile logs a warning for every package whose requested version no longer matches the lock file
+// and for every package that is not in the lock file
+func warnStaleLockFile(lockFile *LockFile, resolvedPackages map[string]*resolver.ResolvedPackage, logger *logger.Logger) {
+	for _, name := range slices.Sorted(maps.Keys(resolvedPackages)) {
+		if _, ok := lockFile.Packages[name]; !ok {
+			logger.LogWarn("%s does not include %s. Run `railpack lock --update` to update it", LockFileName, name)
+			continue
+		}
+
+		if !resolvedPackages[name].Locked {
+			logger.LogWarn("%s is out of date for %s. Run `railpack lock --update` to update it", LockFileName, name)
+		}
+	}
+}
+
+// logUnpinnedImages logs every image in the plan that is not pinned to a digest.
+// Unpinned images are only a warning when the app has a lock file that is missing them.
+func logUnpinnedImages(buildPlan *plan.BuildPlan, lockFile *LockFile, logger *logger.Logger) {
+	for _, image := range buildPlan.GetImages() {
+		if registry.IsPinned(image) {
+			continue
+		}
+
+		if lockFile != nil {
+			logger.LogWarn("%s does not include a digest for image %s. Run `railpack lock --update` to update it", LockFileName, image)
+		} else {
+			logger.LogInfo("Image %s is not pinned to a digest. Run `railpack lock` or use `--pin-images` to pin it", image)
+		}
+	}
+}
+
+func stripDigest(image string) string {
+	ref, _, _ := strings.Cut(image, "@")
+	return ref
+}
+
+// pinImageDigests resolves the current digest of every unpinned image in the plan and pins the plan to it
+func pinImageDigests(buildPlan *plan.BuildPlan, logger *logger.Logger) {
+	digests := map[string]string{}
+
+	for _, image := range buildPlan.GetImages() {
+		if registry.IsPinned(image) {
+			continue
+		}
+
+		digest, err := registry.ResolveDigest(image)
+		if err != nil {
+			logger.LogWarn("Could not resolve the digest of image %s: %s", image, err.Error())
+			continue
+		}
+		digests[image] = digest
+	}
+
+	buildPlan.PinImages(digests)
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/railwayapp/railpack/core/app"
+	"github.com/railwayapp/railpack/core/logger"
+	"github.com/railwayapp/railpack/core/plan"
+	"github.com/railwayapp/railpack/core/resolver"
+	testingUtils "github.com/railwayapp/railpack/core/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateBuildPlan_UsesLockFile(t *testing.T) {
+	appDir := testingUtils.CreateAppDir(t, map[string]string{
+		"main.go": "package main\n\nfunc main() {}\n",
+		"go.mod":  "module example.com/app\n\ngo 1.23\n",
+	})
+
+	requestedVersion := "1.23"
+	lockedVersion := "1.23.1"
+	lockFile := &LockFile{
+		Packages: map[string]*resolver.ResolvedPackage{
+			"go": {Name: "go", RequestedVersion: &requestedVersion, ResolvedVersion: &lockedVersion, Source: "go.mod"},
+		},
+		Images: map[string]string{
+			plan.RailpackBuilderImage: "sha256:builder",
+			plan.RailpackRuntimeImage: "sha256:runtime",
+		},
+	}
+	require.NoError(t, lockFile.Write(appDir))
+
+	userApp, err := app.NewApp(appDir)
+	require.NoError(t, err)
+
+	t.Run("honours lock file", func(t *testing.T) {
+		buildResult := GenerateBuildPlan(userApp, app.NewEnvironment(nil), &GenerateBuildPlanOptions{})
+		require.True(t, buildResult.Success)
+
+		goPkg := buildResult.ResolvedPackages["go"]
+		require.NotNil(t, goPkg)
+		require.Equal(t, "1.23.1", *goPkg.ResolvedVersion)
+		require.True(t, goPkg.Locked)
+
+		require.Contains(t, buildResult.Plan.GetImages(), plan.RailpackBuilderImage+"@sha256:builder")
+		require.Contains(t, buildResult.Plan.GetImages(), plan.RailpackRuntimeImage+"@sha256:runtime")
+		require.Equal(t, "sha256:runtime", buildResult.Plan.Images[plan.RailpackRuntimeImage])
+	})
+
+	t.Run("ignores lock file", func(t *testing.T) {
+		buildResult := GenerateBuildPlan(userApp, app.NewEnvironment(nil), &GenerateBuildPlanOptions{IgnoreLockFile: true})
+		require.True(t, buildResult.Success)
+
+		goPkg := buildResult.ResolvedPackages["go"]
+		require.NotNil(t, goPkg)
+		require.False(t, goPkg.Locked)
+		require.Contains(t, buildResult.Plan.GetImages(), plan.RailpackBuilderImage)
+	})
+}
+
+func TestNewLockFile_KeepsPinnedImages(t *testing.T) {
+	buildPlan := plan.NewBuildPlan()
+	buildPlan.Deploy.Base = plan.NewImageLayer(plan.RailpackRuntimeImage + "@sha256:runtime")
+
+	lockFile, err := NewLockFile(&BuildResult{Plan: buildPlan})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{plan.RailpackRuntimeImage: "sha256:runtime"}, lockFile.Images)
+}
+
+func TestGenerateBuildPlan_LockFileWarnings(t *testing.T) {
+	appDir := testingUtils.CreateAppDir(t, map[string]string{
+		"main.go": "package main\n\nfunc main() {}\n",
+		"go.mod":  "module example.com/app\n\ngo 1.23\n",
+	})
+
+	messages := func(buildResult *BuildResult, level logger.Level) []string {
+		messages := []string{}
+		for _, log := range buildResult.Logs {
+			if log.Level == level {
+				messages = append(messages, log.Msg)
+			}
+		}
+		return messages
+	}
+
+	userApp, err := app.NewApp(appDir)
+	require.NoError(t, err)
+
+	t.Run("without lock file", func(t *testing.T) {
+		buildResult := GenerateBuildPlan(userApp, app.NewEnvironment(nil), &GenerateBuildPlanOptions{})
+		require.True(t, buildResult.Success)
+		require.Contains(t, messages(buildResult, logger.Info), "Image "+plan.RailpackRuntimeImage+" is not pinned to a digest. Run `railpack lock` or use `--pin-images` to pin it")
+		require.Empty(t, messages(buildResult, logger.Warn))
+	})
+
+	lockFile := &LockFile{
+		Packages: map[string]*resolver.ResolvedPackage{},
+		Images: map[string]string{
+			plan.RailpackBuilderImage: "sha256:builder",
+		},
+	}
+	require.NoError(t, lockFile.Write(appDir))
+
+	userApp, err = app.NewApp(appDir)
+	require.NoError(t, err)
+
+	t.Run("incomplete lock file", func(t *testing.T) {
+		buildResult := GenerateBuildPlan(userApp, app.NewEnvironment(nil), &GenerateBuildPlanOptions{})
+		require.True(t, buildResult.Success)
+
+		warnings := messages(buildResult, logger.Warn)
+		require.Contains(t, warnings, "railpack.lock does not include go. Run `railpack lock --update` to update it")
+		require.Contains(t, warnings, "railpack.lock does not include a digest for image "+plan.RailpackRuntimeImage+". Run `railpack lock --update` to update it")
+		require.NotContains(t, warnings, "railpack.lock does not include a digest for image "+plan.RailpackBuilderImage+". Run `railpack lock --update` to update it")
+	})
+}
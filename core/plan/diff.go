@@ -0,0 +1,342 @@
+package plan
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// Change is a single semantic difference between two build plans
+type Change struct {
+	Type ChangeType `json:"type"`
+
+	// Dotted path to the changed value (e.g. "steps.build.commands")
+	Path string `json:"path"`
+
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+func (c Change) String() string {
+	switch c.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, c.New)
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, c.Old)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, c.Old, c.New)
+	}
+}
+
+// Diff returns the semantic differences between two build plans.
+// Steps are matched by name and layers are matched by their source, so reordering alone is not reported as a change.
+func Diff(oldPlan, newPlan *BuildPlan) []Change {
+	if oldPlan == nil {
+		oldPlan = NewBuildPlan()
+	}
+	if newPlan == nil {
+		newPlan = NewBuildPlan()
+	}
+
+	changes := []Change{}
+
+	oldSteps := stepsByName(oldPlan.Steps)
+	newSteps := stepsByName(newPlan.Steps)
+
+	for _, name := range slices.Sorted(maps.Keys(oldSteps)) {
+		if _, ok := newSteps[name]; !ok {
+			changes = append(changes, Change{Type: ChangeRemoved, Path: "steps." + name, Old: name})
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(newSteps)) {
+		newStep := newSteps[name]
+		oldStep, ok := oldSteps[name]
+		if !ok {
+			changes = append(changes, Change{Type: ChangeAdded, Path: "steps." + name, New: name})
+			continue
+		}
+
+		changes = append(changes, diffStep(oldStep, newStep)...)
+	}
+
+	changes = append(changes, diffCaches(oldPlan.Caches, newPlan.Caches)...)
+	changes = append(changes, diffStringSets("secrets", oldPlan.Secrets, newPlan.Secrets)...)
+	changes = append(changes, diffStringMaps("images", oldPlan.Images, newPlan.Images)...)
+	changes = append(changes, diffDeploy(&oldPlan.Deploy, &newPlan.Deploy)...)
+
+	return changes
+}
+
+func stepsByName(steps []Step) map[string]*Step {
+	result := make(map[string]*Step, len(steps))
+	for i := range steps {
+		result[steps[i].Name] = &steps[i]
+	}
+	return result
+}
+
+func diffStep(oldStep, newStep *Step) []Change {
+	prefix := "steps." + newStep.Name
+
+	changes := []Change{}
+	changes = append(changes, diffLayers(prefix+".inputs", oldStep.Inputs, newStep.Inputs)...)
+	changes = append(changes, diffLines(prefix+".commands", commandStrings(oldStep.Commands), commandStrings(newStep.Commands))...)
+	changes = append(changes, diffStringMaps(prefix+".variables", oldStep.Variables, newStep.Variables)...)
+	changes = append(changes, diffStringMaps(prefix+".assets", assetDigests(oldStep.Assets), assetDigests(newStep.Assets))...)
+	changes = append(changes, diffStringSets(prefix+".caches", oldStep.Caches, newStep.Caches)...)
+	changes = append(changes, diffStringSets(prefix+".secrets", oldStep.Secrets, newStep.Secrets)...)
+
+	return changes
+}
+
+// assetDigests replaces asset contents with a short digest so multi-line files stay readable in the diff
+func assetDigests(assets map[string]string) map[string]string {
+	result := make(map[string]string, len(assets))
+	for name, contents := range assets {
+		result[name] = fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(contents)))[:19]
+	}
+	return result
+}
+
+func diffDeploy(oldDeploy, newDeploy *Deploy) []Change {
+	changes := []Change{}
+
+	changes = append(changes, diffLayers("deploy.base", []Layer{oldDeploy.Base}, []Layer{newDeploy.Base})...)
+	changes = append(changes, diffLayers("deploy.inputs", oldDeploy.Inputs, newDeploy.Inputs)...)
+	changes = append(changes, diffString("deploy.startCommand", oldDeploy.StartCmd, newDeploy.StartCmd)...)
+	changes = append(changes, diffString("deploy.startCommandHost", oldDeploy.StartCmdHost, newDeploy.StartCmdHost)...)
+	changes = append(changes, diffString("deploy.releaseCommand", oldDeploy.ReleaseCmd, newDeploy.ReleaseCmd)...)
+	changes = append(changes, diffString("deploy.requiredPort", oldDeploy.RequiredPort, newDeploy.RequiredPort)...)
+	changes = append(changes, diffStringMaps("deploy.variables", oldDeploy.Variables, newDeploy.Variables)...)
+	changes = append(changes, diffLines("deploy.paths", oldDeploy.Paths, newDeploy.Paths)...)
+	changes = append(changes, diffString("deploy.noShell", strconv.FormatBool(oldDeploy.NoShell), strconv.FormatBool(newDeploy.NoShell))...)
+
+	return changes
+}
+
+func diffCaches(oldCaches, newCaches map[string]*Cache) []Change {
+	toStrings := func(caches map[string]*Cache) map[string]string {
+		result := make(map[string]string, len(caches))
+		for name, cache := range caches {
+			if cache != nil {
+				result[name] = fmt.Sprintf("%s (%s)", cache.Directory, cache.Type)
+			}
+		}
+		return result
+	}
+
+	return diffStringMaps("caches", toStrings(oldCaches), toStrings(newCaches))
+}
+
+// layerSource identifies where a layer's files come from, ignoring its filters
+func layerSource(layer Layer) string {
+	switch {
+	case layer.Step != "":
+		return "$" + layer.Step
+	case layer.Image != "":
+		return layer.Image
+	case layer.Local:
+		return "local"
+	case layer.Spread:
+		return "..."
+	}
+	return ""
+}
+
+// diffLayers matches layers by their source. When a source appears more than once
+// (e.g. two filtered layers from the same step), occurrences are matched in order.
+func diffLayers(path string, oldLayers, newLayers []Layer) []Change {
+	keyed := func(layers []Layer) ([]string, map[string]Layer) {
+		keys := []string{}
+		byKey := map[string]Layer{}
+		counts := map[string]int{}
+		for _, layer := range layers {
+			source := layerSource(layer)
+			key := source
+			if counts[source] > 0 {
+				key = fmt.Sprintf("%s#%d", source, counts[source])
+			}
+			counts[source]++
+			keys = append(keys, key)
+			byKey[key] = layer
+		}
+		return keys, byKey
+	}
+
+	oldKeys, oldByKey := keyed(oldLayers)
+	newKeys, newByKey := keyed(newLayers)
+
+	changes := []Change{}
+
+	for _, key := range oldKeys {
+		if _, ok := newByKey[key]; !ok {
+			changes = append(changes, Change{Type: ChangeRemoved, Path: path, Old: describeLayer(oldByKey[key])})
+		}
+	}
+
+	for _, key := range newKeys {
+		newLayer := newByKey[key]
+		oldLayer, ok := oldByKey[key]
+		if !ok {
+			changes = append(changes, Change{Type: ChangeAdded, Path: path, New: describeLayer(newLayer)})
+			continue
+		}
+
+		layerPath := fmt.Sprintf("%s[%s]", path, key)
+		changes = append(changes, diffStringSets(layerPath+".include", oldLayer.Include, newLayer.Include)...)
+		changes = append(changes, diffStringSets(layerPath+".exclude", oldLayer.Exclude, newLayer.Exclude)...)
+	}
+
+	return changes
+}
+
+func describeLayer(layer Layer) string {
+	description := layerSource(layer)
+	if len(layer.Include) > 0 {
+		description += " include=" + strings.Join(layer.Include, ",")
+	}
+	if len(layer.Exclude) > 0 {
+		description += " exclude=" + strings.Join(layer.Exclude, ",")
+	}
+	return description
+}
+
+// CommandString returns a single line, human readable form of a command
+func CommandString(cmd Command) string {
+	switch c := cmd.(type) {
+	case ExecCommand:
+		return c.Cmd
+	case PathCommand:
+		return "PATH " + c.Path
+	case CopyCommand:
+		if c.Image != "" {
+			return fmt.Sprintf("COPY --from=%s %s %s", c.Image, c.Src, c.Dest)
+		}
+		return fmt.Sprintf("COPY %s %s", c.Src, c.Dest)
+	case FileCommand:
+		return fmt.Sprintf("FILE %s %s", c.Path, c.Name)
+	}
+	return cmd.CommandType()
+}
+
+func commandStrings(commands []Command) []string {
+	result := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		result = append(result, CommandString(cmd))
+	}
+	return result
+}
+
+func diffString(path, oldValue, newValue string) []Change {
+	switch {
+	case oldValue == newValue:
+		return nil
+	case oldValue == "":
+		return []Change{{Type: ChangeAdded, Path: path, New: newValue}}
+	case newValue == "":
+		return []Change{{Type: ChangeRemoved, Path: path, Old: oldValue}}
+	}
+	return []Change{{Type: ChangeModified, Path: path, Old: oldValue, New: newValue}}
+}
+
+func diffStringMaps(path string, oldMap, newMap map[string]string) []Change {
+	changes := []Change{}
+
+	keys := slices.Sorted(maps.Keys(oldMap))
+	for key := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		oldValue, inOld := oldMap[key]
+		newValue, inNew := newMap[key]
+
+		switch {
+		case inOld && !inNew:
+			changes = append(changes, Change{Type: ChangeRemoved, Path: path + "." + key, Old: oldValue})
+		case !inOld && inNew:
+			changes = append(changes, Change{Type: ChangeAdded, Path: path + "." + key, New: newValue})
+		case oldValue != newValue:
+			changes = append(changes, Change{Type: ChangeModified, Path: path + "." + key, Old: oldValue, New: newValue})
+		}
+	}
+
+	return changes
+}
+
+// diffStringSets reports values that were added or removed, ignoring order
+func diffStringSets(path string, oldValues, newValues []string) []Change {
+	changes := []Change{}
+
+	for _, value := range oldValues {
+		if !slices.Contains(newValues, value) {
+			changes = append(changes, Change{Type: ChangeRemoved, Path: path, Old: value})
+		}
+	}
+
+	for _, value := range newValues {
+		if !slices.Contains(oldValues, value) {
+			changes = append(changes, Change{Type: ChangeAdded, Path: path, New: value})
+		}
+	}
+
+	return changes
+}
+
+// diffLines reports an ordered line diff between two lists using the longest common subsequence
+func diffLines(path string, oldLines, newLines []string) []Change {
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	changes := []Change{}
+	i, j := 0, 0
+	for i < len(oldLines) && j < len(newLines) {
+		switch {
+		case oldLines[i] == newLines[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			changes = append(changes, Change{Type: ChangeRemoved, Path: path, Old: oldLines[i]})
+			i++
+		default:
+			changes = append(changes, Change{Type: ChangeAdded, Path: path, New: newLines[j]})
+			j++
+		}
+	}
+
+	for ; i < len(oldLines); i++ {
+		changes = append(changes, Change{Type: ChangeRemoved, Path: path, Old: oldLines[i]})
+	}
+	for ; j < len(newLines); j++ {
+		changes = append(changes, Change{Type: ChangeAdded, Path: path, New: newLines[j]})
+	}
+
+	return changes
+}
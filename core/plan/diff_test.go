@@ -0,0 +1,101 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	basePlan := func() *BuildPlan {
+		p := NewBuildPlan()
+
+		install := NewStep("install")
+		install.Inputs = []Layer{NewImageLayer(RailpackBuilderImage)}
+		install.Commands = []Command{NewCopyCommand("package.json"), NewExecCommand("npm ci")}
+		p.AddStep(*install)
+
+		build := NewStep("build")
+		build.Inputs = []Layer{NewStepLayer("install"), NewLocalLayer()}
+		build.Commands = []Command{NewExecCommand("npm run build")}
+		p.AddStep(*build)
+
+		p.Deploy = Deploy{
+			Base:     NewImageLayer(RailpackRuntimeImage),
+			Inputs:   []Layer{NewStepLayer("build", NewIncludeFilter([]string{"."}))},
+			StartCmd: "npm start",
+		}
+
+		return p
+	}
+
+	t.Run("identical plans", func(t *testing.T) {
+		require.Empty(t, Diff(basePlan(), basePlan()))
+	})
+
+	t.Run("added and removed steps", func(t *testing.T) {
+		newPlan := basePlan()
+		newPlan.Steps = newPlan.Steps[:1]
+		newPlan.AddStep(*NewStep("prune"))
+
+		changes := Diff(basePlan(), newPlan)
+		require.Contains(t, changes, Change{Type: ChangeRemoved, Path: "steps.build", Old: "build"})
+		require.Contains(t, changes, Change{Type: ChangeAdded, Path: "steps.prune", New: "prune"})
+	})
+
+	t.Run("changed commands", func(t *testing.T) {
+		newPlan := basePlan()
+		newPlan.Steps[0].Commands = []Command{NewCopyCommand("package.json"), NewExecCommand("npm install")}
+
+		changes := Diff(basePlan(), newPlan)
+		require.Equal(t, []Change{
+			{Type: ChangeRemoved, Path: "steps.install.commands", Old: "npm ci"},
+			{Type: ChangeAdded, Path: "steps.install.commands", New: "npm install"},
+		}, changes)
+	})
+
+	t.Run("layer filter changes", func(t *testing.T) {
+		newPlan := basePlan()
+		newPlan.Deploy.Inputs = []Layer{NewStepLayer("build", NewFilter([]string{"."}, []string{"node_modules"}))}
+
+		changes := Diff(basePlan(), newPlan)
+		require.Equal(t, []Change{
+			{Type: ChangeAdded, Path: "deploy.inputs[$build].exclude", New: "node_modules"},
+		}, changes)
+	})
+
+	t.Run("deploy changes", func(t *testing.T) {
+		newPlan := basePlan()
+		newPlan.Deploy.StartCmd = "node server.js"
+		newPlan.Deploy.Variables = map[string]string{"NODE_ENV": "production"}
+
+		changes := Diff(basePlan(), newPlan)
+		require.Equal(t, []Change{
+			{Type: ChangeModified, Path: "deploy.startCommand", Old: "npm start", New: "node server.js"},
+			{Type: ChangeAdded, Path: "deploy.variables.NODE_ENV", New: "production"},
+		}, changes)
+	})
+	t.Run("start command run without a shell", func(t *testing.T) {
+		newPlan := basePlan()
+		newPlan.Deploy.Base = NewImageLayer(DistrolessStaticImage)
+		newPlan.Deploy.StartCmd = "/app/server"
+		newPlan.Deploy.NoShell = true
+
+		changes := Diff(basePlan(), newPlan)
+		require.Contains(t, changes, Change{Type: ChangeModified, Path: "deploy.noShell", Old: "false", New: "true"})
+	})
+
+	t.Run("pinned image changes", func(t *testing.T) {
+		oldPlan := basePlan()
+		oldPlan.Images = map[string]string{RailpackRuntimeImage: "sha256:aaa"}
+
+		newPlan := basePlan()
+		newPlan.Images = map[string]string{RailpackRuntimeImage: "sha256:bbb", RailpackBuilderImage: "sha256:ccc"}
+
+		changes := Diff(oldPlan, newPlan)
+		require.Equal(t, []Change{
+			{Type: ChangeAdded, Path: "images." + RailpackBuilderImage, New: "sha256:ccc"},
+			{Type: ChangeModified, Path: "images." + RailpackRuntimeImage, Old: "sha256:aaa", New: "sha256:bbb"},
+		}, changes)
+	})
+}
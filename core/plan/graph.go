@@ -0,0 +1,91 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidateStepGraph checks that every step input references an existing step
+// and that the steps do not depend on each other in a cycle.
+// All problems are returned together so they can be fixed at once.
+func (p *BuildPlan) ValidateStepGraph() error {
+	steps := stepsByName(p.Steps)
+	errs := []error{}
+
+	for _, step := range p.Steps {
+		for _, input := range step.Inputs {
+			switch {
+			case input.Step == "":
+				continue
+			case input.Step == step.Name:
+				errs = append(errs, fmt.Errorf("step `%s` uses itself as an input", step.Name))
+			case steps[input.Step] == nil:
+				errs = append(errs, fmt.Errorf("step `%s` has an input from step `%s` which does not exist", step.Name, input.Step))
+			}
+		}
+	}
+
+	if p.Deploy.Base.Step != "" && steps[p.Deploy.Base.Step] == nil {
+		errs = append(errs, fmt.Errorf("deploy base uses step `%s` which does not exist", p.Deploy.Base.Step))
+	}
+
+	for _, input := range p.Deploy.Inputs {
+		if input.Step != "" && steps[input.Step] == nil {
+			errs = append(errs, fmt.Errorf("deploy has an input from step `%s` which does not exist", input.Step))
+		}
+	}
+
+	for _, cycle := range p.findStepCycles() {
+		errs = append(errs, fmt.Errorf("steps depend on each other in a cycle: %s", strings.Join(cycle, " -> ")))
+	}
+
+	return errors.Join(errs...)
+}
+
+// findStepCycles returns the path of every cycle between steps, starting and ending with the same step.
+// Self references are not included since they are reported separately.
+func (p *BuildPlan) findStepCycles() [][]string {
+	steps := stepsByName(p.Steps)
+	cycles := [][]string{}
+
+	visited := map[string]bool{}
+	onPath := map[string]int{}
+	path := []string{}
+
+	var visit func(name string)
+	visit = func(name string) {
+		if index, ok := onPath[name]; ok {
+			cycle := append([]string{}, path[index:]...)
+			cycles = append(cycles, append(cycle, name))
+			return
+		}
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+
+		step := steps[name]
+		if step == nil {
+			return
+		}
+
+		onPath[name] = len(path)
+		path = append(path, name)
+
+		for _, input := range step.Inputs {
+			if input.Step != "" && input.Step != name {
+				visit(input.Step)
+			}
+		}
+
+		path = path[:len(path)-1]
+		delete(onPath, name)
+	}
+
+	for _, step := range p.Steps {
+		visit(step.Name)
+	}
+
+	return cycles
+}
@@ -0,0 +1,80 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStepGraph(t *testing.T) {
+	tests := []struct {
+		name     string
+		steps    []Step
+		deploy   Deploy
+		expected string
+	}{
+		{
+			name: "valid graph",
+			steps: []Step{
+				{Name: "install", Inputs: []Layer{NewImageLayer(RailpackBuilderImage)}},
+				{Name: "build", Inputs: []Layer{NewStepLayer("install"), NewLocalLayer()}},
+			},
+			deploy: Deploy{Inputs: []Layer{NewStepLayer("build")}},
+		},
+		{
+			name: "missing step",
+			steps: []Step{
+				{Name: "build", Inputs: []Layer{NewStepLayer("instal")}},
+			},
+			deploy:   Deploy{Base: NewStepLayer("runtime"), Inputs: []Layer{NewStepLayer("build"), NewStepLayer("test")}},
+			expected: "step `build` has an input from step `instal` which does not exist\ndeploy base uses step `runtime` which does not exist\ndeploy has an input from step `test` which does not exist",
+		},
+		{
+			name: "self reference",
+			steps: []Step{
+				{Name: "build", Inputs: []Layer{NewImageLayer(RailpackBuilderImage), NewStepLayer("build")}},
+			},
+			expected: "step `build` uses itself as an input",
+		},
+		{
+			name: "cycle",
+			steps: []Step{
+				{Name: "install", Inputs: []Layer{NewStepLayer("build")}},
+				{Name: "prepare", Inputs: []Layer{NewStepLayer("install")}},
+				{Name: "build", Inputs: []Layer{NewStepLayer("prepare")}},
+				{Name: "test", Inputs: []Layer{NewStepLayer("build")}},
+			},
+			expected: "steps depend on each other in a cycle: install -> build -> prepare -> install",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &BuildPlan{Steps: tt.steps, Deploy: tt.deploy}
+
+			err := p.ValidateStepGraph()
+			if tt.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestNormalizeWithCycle(t *testing.T) {
+	p := &BuildPlan{
+		Steps: []Step{
+			{Name: "a", Inputs: []Layer{NewStepLayer("b")}},
+			{Name: "b", Inputs: []Layer{NewStepLayer("a")}},
+			{Name: "unused", Inputs: []Layer{NewImageLayer(RailpackBuilderImage)}},
+		},
+		Deploy: Deploy{Inputs: []Layer{NewStepLayer("a")}},
+	}
+
+	p.Normalize()
+	require.Len(t, p.Steps, 2)
+	require.Equal(t, "a", p.Steps[0].Name)
+	require.Equal(t, "b", p.Steps[1].Name)
+}
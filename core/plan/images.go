@@ -0,0 +1,86 @@
+package plan
+
+import (
+	"slices"
+	"strings"
+)
+
+// GetImages returns all the image references used by the plan
+func (p *BuildPlan) GetImages() []string {
+	images := []string{}
+
+	addImage := func(image string) {
+		if image != "" && image != ScratchImage && !slices.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+
+	for _, step := range p.Steps {
+		for _, input := range step.Inputs {
+			addImage(input.Image)
+		}
+		for _, cmd := range step.Commands {
+			if copyCmd, ok := cmd.(CopyCommand); ok {
+				addImage(copyCmd.Image)
+			}
+		}
+	}
+
+	addImage(p.Deploy.Base.Image)
+	for _, input := range p.Deploy.Inputs {
+		addImage(input.Image)
+	}
+
+	if len(p.Secrets) > 0 {
+		addImage(p.GetSecretsImage())
+	}
+
+	return images
+}
+
+// GetSecretsImage returns the image used to hash secrets, pinned to a digest if one is recorded in the plan
+func (p *BuildPlan) GetSecretsImage() string {
+	if digest := p.Images[SecretsImage]; digest != "" {
+		return SecretsImage + "@" + digest
+	}
+	return SecretsImage
+}
+
+// PinImages replaces every image reference that has a known digest with a reference pinned to that digest.
+// The digests that are used are recorded in the plan.
+func (p *BuildPlan) PinImages(digests map[string]string) {
+	pin := func(image string) string {
+		if image == "" || strings.Contains(image, "@") {
+			return image
+		}
+		if digest, ok := digests[image]; ok && digest != "" {
+			if p.Images == nil {
+				p.Images = map[string]string{}
+			}
+			p.Images[image] = digest
+			return image + "@" + digest
+		}
+		return image
+	}
+
+	for i := range p.Steps {
+		for j := range p.Steps[i].Inputs {
+			p.Steps[i].Inputs[j].Image = pin(p.Steps[i].Inputs[j].Image)
+		}
+		for j, cmd := range p.Steps[i].Commands {
+			if copyCmd, ok := cmd.(CopyCommand); ok {
+				copyCmd.Image = pin(copyCmd.Image)
+				p.Steps[i].Commands[j] = copyCmd
+			}
+		}
+	}
+
+	p.Deploy.Base.Image = pin(p.Deploy.Base.Image)
+	for i := range p.Deploy.Inputs {
+		p.Deploy.Inputs[i].Image = pin(p.Deploy.Inputs[i].Image)
+	}
+
+	if len(p.Secrets) > 0 {
+		pin(SecretsImage)
+	}
+}
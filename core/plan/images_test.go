@@ -0,0 +1,45 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinImages(t *testing.T) {
+	p := NewBuildPlan()
+
+	build := NewStep("build")
+	build.Inputs = []Layer{NewImageLayer(RailpackBuilderImage)}
+	build.Commands = []Command{CopyCommand{Image: "caddy:latest", Src: "/usr/bin/caddy", Dest: "/usr/bin/caddy"}}
+	p.AddStep(*build)
+
+	p.Deploy.Base = NewImageLayer(RailpackRuntimeImage)
+	p.Secrets = []string{"API_KEY"}
+
+	require.Equal(t, []string{RailpackBuilderImage, "caddy:latest", RailpackRuntimeImage, SecretsImage}, p.GetImages())
+
+	p.PinImages(map[string]string{
+		RailpackBuilderImage: "sha256:builder",
+		RailpackRuntimeImage: "sha256:runtime",
+		SecretsImage:         "sha256:alpine",
+	})
+
+	require.Equal(t, RailpackBuilderImage+"@sha256:builder", p.Steps[0].Inputs[0].Image)
+	require.Equal(t, "caddy:latest", p.Steps[0].Commands[0].(CopyCommand).Image)
+	require.Equal(t, RailpackRuntimeImage+"@sha256:runtime", p.Deploy.Base.Image)
+	require.Equal(t, SecretsImage+"@sha256:alpine", p.GetSecretsImage())
+
+	require.Equal(t, map[string]string{
+		RailpackBuilderImage: "sha256:builder",
+		RailpackRuntimeImage: "sha256:runtime",
+		SecretsImage:         "sha256:alpine",
+	}, p.Images)
+}
+
+func TestGetImagesScratch(t *testing.T) {
+	p := NewBuildPlan()
+	p.Deploy.Base = NewImageLayer(ScratchImage)
+
+	require.Empty(t, p.GetImages())
+}
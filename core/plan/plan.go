@@ -1,8 +1,26 @@
 package plan
 
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
 const (
 	RailpackBuilderImage = "ghcr.io/railwayapp/railpack-builder:latest"
 	RailpackRuntimeImage = "ghcr.io/railwayapp/railpack-runtime:latest"
+
+	// An empty base image, as in `FROM scratch`
+	ScratchImage = "scratch"
+
+	// A minimal base image for statically linked binaries. It includes CA certificates and tzdata but no shell
+	DistrolessStaticImage = "gcr.io/distroless/static-debian12"
+
+	// A minimal base image for binaries that are dynamically linked against glibc. It adds glibc and libstdc++ to the static image
+	DistrolessCCImage = "gcr.io/distroless/cc-debian12"
+
+	// Image used to hash the secrets used by a step
+	SecretsImage = "alpine:latest"
 )
 
 type BuildPlan struct {
@@ -10,6 +28,9 @@ type BuildPlan struct {
 	Caches  map[string]*Cache `json:"caches,omitempty"`
 	Secrets []string          `json:"secrets,omitempty"`
 	Deploy  Deploy            `json:"deploy,omitempty"`
+
+	// Map of image reference to the digest it is pinned to
+	Images map[string]string `json:"images,omitempty"`
 }
 
 type Deploy struct {
@@ -25,6 +46,9 @@ type Deploy struct {
 	// Optional full host-binding command to run on the host (e.g. "npm start -- --host")
 	StartCmdHost string `json:"startCommandHost,omitempty"`
 
+	// The command to run once before a new deployment is started (e.g. database migrations)
+	ReleaseCmd string `json:"releaseCommand,omitempty"`
+
 	// The port(s) required by the application (e.g. 80, "3000,8080")
 	RequiredPort string `json:"requiredPort,omitempty"`
 
@@ -33,6 +57,19 @@ type Deploy struct {
 
 	// The paths to prepend to the $PATH environment variable
 	Paths []string `json:"paths,omitempty"`
+
+	// Run the start command directly instead of with a shell, for base images that do not have one
+	NoShell bool `json:"noShell,omitempty"`
+}
+
+// Characters that are only interpreted by a shell
+const shellSyntax = "$`&|;<>*?~'\"\\"
+
+// CommandNeedsShell checks if a command uses shell syntax and cannot be run without a shell.
+// Leading environment variable assignments (e.g. PORT=3000 ./out) are also shell syntax.
+func CommandNeedsShell(cmd string) bool {
+	firstWord, _, _ := strings.Cut(strings.TrimSpace(cmd), " ")
+	return strings.ContainsAny(cmd, shellSyntax) || strings.Contains(firstWord, "=")
 }
 
 func NewBuildPlan() *BuildPlan {
@@ -78,6 +115,40 @@ func (p *BuildPlan) Normalize() {
 		}
 	}
 
+	referencedSteps := p.referencedSteps()
+
+	// Keep only steps that are referenced
+	if len(referencedSteps) > 0 {
+		normalizedSteps := make([]Step, 0, len(p.Steps))
+		for _, step := range p.Steps {
+			if referencedSteps[step.Name] {
+				normalizedSteps = append(normalizedSteps, step)
+			}
+		}
+		p.Steps = normalizedSteps
+	}
+}
+
+// UnreachableSteps returns the names of steps that deploy does not depend on, directly or transitively.
+// These steps are removed when the plan is normalized.
+func (p *BuildPlan) UnreachableSteps() []string {
+	referencedSteps := p.referencedSteps()
+	if len(referencedSteps) == 0 {
+		return []string{}
+	}
+
+	unreachable := []string{}
+	for _, step := range p.Steps {
+		if !referencedSteps[step.Name] {
+			unreachable = append(unreachable, step.Name)
+		}
+	}
+
+	return unreachable
+}
+
+// referencedSteps returns the steps referenced by deploy or transitively referenced by those steps
+func (p *BuildPlan) referencedSteps() map[string]bool {
 	// Track which steps are referenced by deploy or transitively referenced steps
 	referencedSteps := make(map[string]bool)
 
@@ -94,59 +165,26 @@ func (p *BuildPlan) Normalize() {
 		}
 	}
 
-	// Keep finding new referenced steps until no more are found
-	// Use a map to track which steps we've already checked to avoid infinite loops
-	checkedSteps := make(map[string]bool)
-	maxIterations := len(p.Steps) * len(p.Steps) // Maximum possible unique edges in a directed graph
-	iterations := 0
-
-	for {
-		if iterations >= maxIterations {
-			// We've exceeded the maximum possible number of unique edges
-			// This means we have a circular dependency, but we've already
-			// collected all reachable steps, so we can break
-			break
-		}
-		iterations++
+	// Walk the inputs of every referenced step. Each step is only visited once, so cycles between steps are harmless here
+	// and are reported by ValidateStepGraph instead.
+	steps := stepsByName(p.Steps)
+	queue := slices.Collect(maps.Keys(referencedSteps))
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
 
-		newReferences := false
-		for _, step := range p.Steps {
-			// Skip if this step isn't referenced
-			if !referencedSteps[step.Name] {
-				continue
-			}
-
-			// Skip if we've already checked this step's inputs
-			if checkedSteps[step.Name] {
-				continue
-			}
-
-			// Mark this step as checked
-			checkedSteps[step.Name] = true
-
-			// Check this step's inputs for references
-			if step.Inputs != nil {
-				for _, input := range step.Inputs {
-					if input.Step != "" && !referencedSteps[input.Step] {
-						referencedSteps[input.Step] = true
-						newReferences = true
-					}
-				}
-			}
-		}
-		if !newReferences {
-			break
+		step, ok := steps[name]
+		if !ok {
+			continue
 		}
-	}
 
-	// Keep only steps that are referenced
-	if len(referencedSteps) > 0 {
-		normalizedSteps := make([]Step, 0, len(p.Steps))
-		for _, step := range p.Steps {
-			if referencedSteps[step.Name] {
-				normalizedSteps = append(normalizedSteps, step)
+		for _, input := range step.Inputs {
+			if input.Step != "" && !referencedSteps[input.Step] {
+				referencedSteps[input.Step] = true
+				queue = append(queue, input.Step)
 			}
 		}
-		p.Steps = normalizedSteps
 	}
+
+	return referencedSteps
 }
@@ -380,3 +380,32 @@ func TestNormalize(t *testing.T) {
 		})
 	}
 }
+
+func TestUnreachableSteps(t *testing.T) {
+	p := &BuildPlan{
+		Steps: []Step{
+			{Name: "install", Inputs: []Layer{NewImageLayer(RailpackBuilderImage)}},
+			{Name: "build", Inputs: []Layer{NewStepLayer("install")}},
+			{Name: "test", Inputs: []Layer{NewStepLayer("build")}},
+			{Name: "lint", Inputs: []Layer{NewStepLayer("install")}},
+		},
+		Deploy: Deploy{
+			Inputs: []Layer{NewStepLayer("build")},
+		},
+	}
+
+	require.Equal(t, []string{"test", "lint"}, p.UnreachableSteps())
+
+	p.Normalize()
+	require.Empty(t, p.UnreachableSteps())
+}
+
+func TestCommandNeedsShell(t *testing.T) {
+	require.False(t, CommandNeedsShell("./out"))
+	require.False(t, CommandNeedsShell("./bin/server --port 8080"))
+
+	require.True(t, CommandNeedsShell("./out --port $PORT"))
+	require.True(t, CommandNeedsShell("./bin/migrate && ./bin/server"))
+	require.True(t, CommandNeedsShell("./out --name 'my app'"))
+	require.True(t, CommandNeedsShell("PORT=3000 ./out"))
+}
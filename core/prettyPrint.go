@@ -230,6 +230,13 @@ func formatSteps(output *strings.Builder, br *BuildResult) {
 }
 
 func formatDeploy(output *strings.Builder, br *BuildResult) {
+	if br.Plan != nil && br.Plan.Deploy.ReleaseCmd != "" {
+		output.WriteString(sectionHeaderStyle.MarginTop(1).Render("Release"))
+		output.WriteString("\n")
+		output.WriteString(fmt.Sprintf("%s %s", commandPrefixStyle.Render("$"), commandStyle.Render(br.Plan.Deploy.ReleaseCmd)))
+		output.WriteString("\n")
+	}
+
 	if br.Plan != nil && br.Plan.Deploy.StartCmd != "" {
 		output.WriteString(sectionHeaderStyle.MarginTop(1).Render("Deploy"))
 		output.WriteString("\n")
@@ -0,0 +1,59 @@
+package golang
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/generate"
+)
+
+const (
+	GO_BIN_DIR = "bin"
+)
+
+var (
+	mainPackageRegex = regexp.MustCompile(`(?m)^package main\b`)
+
+	// Binaries with these names are started before the others
+	preferredStartBins = []string{"server", "api", "web", "app"}
+)
+
+// getCmdPackages returns the names of the main packages in the cmd directory
+func (p *GoProvider) getCmdPackages(ctx *generate.GenerateContext) []string {
+	names := []string{}
+	for _, file := range ctx.App.FindFilesWithContent("cmd/*/*.go", mainPackageRegex) {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		names = append(names, filepath.Base(filepath.Dir(file)))
+	}
+
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// getConfiguredBins returns the commands in cmd/ listed in RAILPACK_GO_BINS
+func (p *GoProvider) getConfiguredBins(ctx *generate.GenerateContext) []string {
+	bins, _ := ctx.Env.GetConfigVariable("GO_BINS")
+	return strings.FieldsFunc(bins, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+// selectStartBin returns the binary that is started by default. The others can be started
+// by setting the start command or with a Procfile.
+func (p *GoProvider) selectStartBin(ctx *generate.GenerateContext, bins []string) string {
+	for _, name := range preferredStartBins {
+		if slices.Contains(bins, name) {
+			return name
+		}
+	}
+
+	if len(bins) > 1 {
+		ctx.Logger.LogWarn("Multiple commands found in cmd/ (%s). Starting %s, set a start command to run a different binary from %s/", strings.Join(bins, ", "), bins[0], GO_BIN_DIR)
+	}
+
+	return bins[0]
+}
@@ -0,0 +1,46 @@
+package golang
+
+import (
+	"fmt"
+
+	"github.com/railwayapp/railpack/core/generate"
+)
+
+const (
+	GO_WATCH_VAR    = "GO_WATCH"
+	AIR_CONFIG_FILE = ".air.toml"
+	DELVE_PORT      = "2345"
+
+	airPackage   = "go:github.com/air-verse/air"
+	delvePackage = "go:github.com/go-delve/delve/cmd/dlv"
+)
+
+// InstallDevTools installs Delve when debugging and air when the app is rebuilt on changes
+func (p *GoProvider) InstallDevTools(ctx *generate.GenerateContext, miseStep *generate.MiseStepBuilder) {
+	if ctx.Debug {
+		miseStep.Default(delvePackage, "latest")
+	} else if p.shouldWatch(ctx) {
+		miseStep.Default(airPackage, "latest")
+	}
+}
+
+// shouldWatch returns true if the app is rebuilt on changes with air,
+// because the app has an air config or RAILPACK_GO_WATCH is set
+func (p *GoProvider) shouldWatch(ctx *generate.GenerateContext) bool {
+	return ctx.App.HasFile(AIR_CONFIG_FILE) || ctx.Env.IsConfigVariableTruthy(GO_WATCH_VAR)
+}
+
+// getWatchStartCmd runs the app with air. Without an air config, air builds the same package as go run.
+func (p *GoProvider) getWatchStartCmd(ctx *generate.GenerateContext, pkg string) string {
+	if ctx.App.HasFile(AIR_CONFIG_FILE) {
+		return "air"
+	}
+
+	return fmt.Sprintf(`air --build.cmd "go build -o ./tmp/main %s" --build.bin "./tmp/main"`, pkg)
+}
+
+// getDebugStartCmd builds and runs the app with a headless Delve server that debuggers connect to.
+// The app starts without waiting for a debugger to attach.
+func (p *GoProvider) getDebugStartCmd(ctx *generate.GenerateContext, pkg string) string {
+	return fmt.Sprintf("dlv debug %s --headless --listen=0.0.0.0:%s --api-version=2 --accept-multiclient --continue", pkg, DELVE_PORT)
+}
@@ -16,7 +16,14 @@ const (
 	GO_PATH            = "/go"
 )
 
-type GoProvider struct{}
+type GoProvider struct {
+	// bins are the commands built into bin/ when there are several
+	bins     []string
+	startBin string
+
+	// staticBase is the minimal image the binary is deployed on when CGO is disabled
+	staticBase string
+}
 
 func (p *GoProvider) Name() string {
 	return "golang"
@@ -38,29 +45,54 @@ func (p *GoProvider) Plan(ctx *generate.GenerateContext) error {
 	install.AddInput(plan.NewStepLayer(builder.Name()))
 	p.InstallGoDeps(ctx, install)
 
+	if !p.hasCGOEnabled(ctx) {
+		p.staticBase = ctx.GetStaticBase()
+	}
+
 	build := ctx.NewCommandStep("build")
 	build.AddInput(plan.NewStepLayer(install.Name()))
 	p.Build(ctx, build)
 
 	ctx.Deploy.StartCmd = fmt.Sprintf("./%s", GO_BINARY_NAME)
+	if len(p.bins) > 0 {
+		p.startBin = p.selectStartBin(ctx, p.bins)
+		ctx.Deploy.StartCmd = fmt.Sprintf("./%s/%s", GO_BIN_DIR, p.startBin)
+		ctx.Deploy.Paths = append(ctx.Deploy.Paths, "/app/"+GO_BIN_DIR)
+	}
+
 	if ctx.Dev {
 		if dev := p.getDevStartCmd(ctx); dev != "" {
 			ctx.Deploy.StartCmd = dev
 		}
+		if ctx.Debug {
+			ctx.AddDebugPort(DELVE_PORT)
+		}
+		p.InstallDevTools(ctx, builder)
 	}
 
-	runtimePkgs := []string{"tzdata"}
-	if p.hasCGOEnabled(ctx) {
-		ctx.Logger.LogInfo("CGO is enabled")
-		runtimePkgs = append(runtimePkgs, "libc6")
-	}
+	if p.staticBase != "" {
+		// The static binary is the only file that is deployed
+		output := GO_BINARY_NAME
+		if len(p.bins) > 0 {
+			output = GO_BIN_DIR
+		}
 
-	ctx.Deploy.AddAptPackages(runtimePkgs)
-	ctx.Deploy.AddInputs([]plan.Layer{
-		plan.NewStepLayer(build.Name(), plan.Filter{
-			Include: []string{"."},
-		}),
-	})
+		ctx.Logger.LogInfo("Deploying on a %s base image", p.staticBase)
+		ctx.Deploy.UseStaticBase(p.staticBase, build.Name(), []string{output})
+	} else {
+		runtimePkgs := []string{"tzdata"}
+		if p.hasCGOEnabled(ctx) {
+			ctx.Logger.LogInfo("CGO is enabled")
+			runtimePkgs = append(runtimePkgs, "libc6")
+		}
+
+		ctx.Deploy.AddAptPackages(runtimePkgs)
+		ctx.Deploy.AddInputs([]plan.Layer{
+			plan.NewStepLayer(build.Name(), plan.Filter{
+				Include: []string{"."},
+			}),
+		})
+	}
 
 	p.addMetadata(ctx)
 
@@ -70,8 +102,8 @@ func (p *GoProvider) Plan(ctx *generate.GenerateContext) error {
 func (p *GoProvider) Build(ctx *generate.GenerateContext, build *generate.CommandStepBuilder) {
 	var buildCmd string
 
-	flags := "-w -s"
-	baseBuildCmd := fmt.Sprintf("go build -ldflags=\"%s\" -o %s", flags, GO_BINARY_NAME)
+	goBuild := p.goBuildCommand(ctx)
+	baseBuildCmd := fmt.Sprintf("%s -o %s", goBuild, GO_BINARY_NAME)
 
 	if modulePath, _ := ctx.Env.GetConfigVariable("GO_WORKSPACE_MODULE"); modulePath != "" {
 		// Use the provided env var path to build the specified module
@@ -81,9 +113,14 @@ func (p *GoProvider) Build(ctx *generate.GenerateContext, build *generate.Comman
 		// Use the provided env var path to build the specified command
 		ctx.Logger.LogInfo("Building bin: %s", binName)
 		buildCmd = fmt.Sprintf("%s ./cmd/%s", baseBuildCmd, binName)
+	} else if bins := p.getConfiguredBins(ctx); len(bins) > 0 {
+		buildCmd = p.buildBins(ctx, goBuild, bins)
 	} else if p.isGoMod(ctx) && p.hasRootGoFiles(ctx) {
 		// Use the default build command if there are root go files
 		buildCmd = baseBuildCmd
+	} else if cmds := p.getCmdPackages(ctx); len(cmds) > 1 {
+		// Build every command in the cmd directory
+		buildCmd = p.buildBins(ctx, goBuild, cmds)
 	} else if dirs, err := ctx.App.FindDirectories("cmd/*"); err == nil && len(dirs) > 0 {
 		// Try to find a command in the cmd directory if no other build command is specified
 		cmdName := filepath.Base(dirs[0])
@@ -119,6 +156,33 @@ func (p *GoProvider) Build(ctx *generate.GenerateContext, build *generate.Comman
 	})
 }
 
+// goBuildCommand returns the go build command with the flags for a release binary.
+// Binaries deployed on a static base are built without file system paths, and those on scratch embed the time zone database.
+func (p *GoProvider) goBuildCommand(ctx *generate.GenerateContext) string {
+	args := []string{"go build"}
+	if p.staticBase != "" {
+		args = append(args, "-trimpath")
+	}
+	if p.staticBase == generate.StaticBaseScratch {
+		args = append(args, "-tags timetzdata")
+	}
+
+	return strings.Join(append(args, `-ldflags="-w -s"`), " ")
+}
+
+// buildBins builds each command in cmd/ into the bin directory with a single go build
+func (p *GoProvider) buildBins(ctx *generate.GenerateContext, goBuild string, bins []string) string {
+	ctx.Logger.LogInfo("Building commands: %s", strings.Join(bins, ", "))
+	p.bins = bins
+
+	pkgs := []string{}
+	for _, bin := range bins {
+		pkgs = append(pkgs, "./cmd/"+bin)
+	}
+
+	return fmt.Sprintf("%s -o %s/ %s", goBuild, GO_BIN_DIR, strings.Join(pkgs, " "))
+}
+
 func (p *GoProvider) InstallGoDeps(ctx *generate.GenerateContext, install *generate.CommandStepBuilder) {
 	install.AddEnvVars(map[string]string{
 		"GOPATH": GO_PATH,
@@ -187,36 +251,55 @@ func (p *GoProvider) InstallGoPackages(ctx *generate.GenerateContext, miseStep *
 	}
 }
 
+// getDevPackage returns the main package that is run in development, using similar selection logic as Build
+func (p *GoProvider) getDevPackage(ctx *generate.GenerateContext) string {
+	if modulePath, _ := ctx.Env.GetConfigVariable("GO_WORKSPACE_MODULE"); modulePath != "" {
+		return "./" + modulePath
+	}
+	if binName, _ := ctx.Env.GetConfigVariable("GO_BIN"); binName != "" {
+		return "./cmd/" + binName
+	}
+	if p.startBin != "" {
+		return "./cmd/" + p.startBin
+	}
+	if p.isGoMod(ctx) && p.hasRootGoFiles(ctx) {
+		return "."
+	}
+	if dirs, err := ctx.App.FindDirectories("cmd/*"); err == nil && len(dirs) > 0 {
+		return "./cmd/" + filepath.Base(dirs[0])
+	}
+	if p.isGoMod(ctx) {
+		return "."
+	}
+	if p.isGoWorkspace(ctx) {
+		pkgs := p.GoWorkspacePackages(ctx)
+		for _, pkg := range pkgs {
+			if ctx.App.HasMatch(filepath.Join(pkg, "main.go")) {
+				return "./" + pkg
+			}
+		}
+	}
+	if ctx.App.HasMatch("main.go") {
+		return "main.go"
+	}
+	return ""
+}
+
 func (p *GoProvider) getDevStartCmd(ctx *generate.GenerateContext) string {
-    // Prefer go run using similar selection logic as Build
-    if modulePath, _ := ctx.Env.GetConfigVariable("GO_WORKSPACE_MODULE"); modulePath != "" {
-        return fmt.Sprintf("go run ./%s", modulePath)
-    }
-    if binName, _ := ctx.Env.GetConfigVariable("GO_BIN"); binName != "" {
-        return fmt.Sprintf("go run ./cmd/%s", binName)
-    }
-    if p.isGoMod(ctx) && p.hasRootGoFiles(ctx) {
-        return "go run ."
-    }
-    if dirs, err := ctx.App.FindDirectories("cmd/*"); err == nil && len(dirs) > 0 {
-        cmdName := filepath.Base(dirs[0])
-        return fmt.Sprintf("go run ./cmd/%s", cmdName)
-    }
-    if p.isGoMod(ctx) {
-        return "go run ."
-    }
-    if p.isGoWorkspace(ctx) {
-        pkgs := p.GoWorkspacePackages(ctx)
-        for _, pkg := range pkgs {
-            if ctx.App.HasMatch(filepath.Join(pkg, "main.go")) {
-                return fmt.Sprintf("go run ./%s", pkg)
-            }
-        }
-    }
-    if ctx.App.HasMatch("main.go") {
-        return "go run main.go"
-    }
-    return ""
+	pkg := p.getDevPackage(ctx)
+	if pkg == "" {
+		return ""
+	}
+
+	if ctx.Debug {
+		return p.getDebugStartCmd(ctx, pkg)
+	}
+
+	if p.shouldWatch(ctx) {
+		return p.getWatchStartCmd(ctx, pkg)
+	}
+
+	return fmt.Sprintf("go run %s", pkg)
 }
 
 func (p *GoProvider) GetBuilder(ctx *generate.GenerateContext) *generate.MiseStepBuilder {
@@ -235,6 +318,8 @@ func (p *GoProvider) addMetadata(ctx *generate.GenerateContext) {
 	ctx.Metadata.SetBool("goRootFile", p.hasRootGoFiles(ctx))
 	ctx.Metadata.SetBool("goGin", p.isGin(ctx))
 	ctx.Metadata.SetBool("goCGO", p.hasCGOEnabled(ctx))
+	ctx.Metadata.Set("goBins", strings.Join(p.bins, ","))
+	ctx.Metadata.Set("goStaticBase", p.staticBase)
 }
 
 func (p *GoProvider) goBuildCache(ctx *generate.GenerateContext) string {
@@ -297,5 +382,6 @@ func (p *GoProvider) StartCommandHelp() string {
 		"1. Create a main.go file in your project root\n\n" +
 		"2. Create a command in the cmd directory (e.g., cmd/server/main.go)\n\n" +
 		"3. Set the GO_BIN environment variable to specify which command to build\n\n" +
+		"   With several commands in cmd/, each is built into bin/ and added to the PATH. Set GO_BINS to choose which ones\n\n" +
 		"4. For workspaces: Set GO_WORKSPACE_MODULE to build a specific module (e.g., GO_WORKSPACE_MODULE=api)"
 }
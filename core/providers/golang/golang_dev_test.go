@@ -3,6 +3,7 @@ package golang
 import (
     "testing"
 
+    "github.com/railwayapp/railpack/core/generate"
     testingUtils "github.com/railwayapp/railpack/core/testing"
     "github.com/stretchr/testify/require"
 )
@@ -26,3 +27,53 @@ func TestGo_Dev_UsesGoRun(t *testing.T) {
 }
 
 
+
+func TestGo_Dev_Debug(t *testing.T) {
+    ctx := testingUtils.CreateGenerateContext(t, "../../../examples/go-mod")
+    ctx.Dev = true
+    ctx.Debug = true
+
+    provider := GoProvider{}
+    require.NoError(t, provider.Initialize(ctx))
+    require.NoError(t, provider.Plan(ctx))
+
+    require.Equal(t, "dlv debug . --headless --listen=0.0.0.0:2345 --api-version=2 --accept-multiclient --continue", ctx.Deploy.StartCmd)
+    require.Equal(t, DELVE_PORT, ctx.Deploy.RequiredPort)
+    require.Contains(t, misePackageNames(ctx), delvePackage)
+}
+
+func TestGo_Dev_Watch(t *testing.T) {
+    t.Run("air config", func(t *testing.T) {
+        ctx := testingUtils.CreateGenerateContextFromFiles(t, map[string]string{
+            "go.mod":    "module app\n\ngo 1.23\n",
+            "main.go":   "package main\n\nfunc main() {}\n",
+            ".air.toml": "[build]\n",
+        })
+        ctx.Dev = true
+
+        provider := GoProvider{}
+        require.NoError(t, provider.Plan(ctx))
+
+        require.Equal(t, "air", ctx.Deploy.StartCmd)
+        require.Contains(t, misePackageNames(ctx), airPackage)
+    })
+
+    t.Run("watch requested", func(t *testing.T) {
+        ctx := testingUtils.CreateGenerateContext(t, "../../../examples/go-cmd-dirs")
+        ctx.Dev = true
+        ctx.Env.SetVariable("RAILPACK_GO_WATCH", "true")
+
+        provider := GoProvider{}
+        require.NoError(t, provider.Plan(ctx))
+
+        require.Equal(t, `air --build.cmd "go build -o ./tmp/main ./cmd/server" --build.bin "./tmp/main"`, ctx.Deploy.StartCmd)
+    })
+}
+
+func misePackageNames(ctx *generate.GenerateContext) []string {
+    names := []string{}
+    for _, pkg := range ctx.GetMiseStepBuilder().MisePackages {
+        names = append(names, pkg.Name)
+    }
+    return names
+}
@@ -3,6 +3,9 @@ package golang
 import (
 	"testing"
 
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/logger"
+	"github.com/railwayapp/railpack/core/plan"
 	testingUtils "github.com/railwayapp/railpack/core/testing"
 	"github.com/stretchr/testify/require"
 )
@@ -78,3 +81,123 @@ func TestGolang(t *testing.T) {
 		})
 	}
 }
+
+func TestGolangMultipleBins(t *testing.T) {
+	tests := []struct {
+		name     string
+		bins     string
+		buildCmd string
+		startCmd string
+		devCmd   string
+	}{
+		{
+			name:     "every cmd",
+			buildCmd: "go build -ldflags=\"-w -s\" -o bin/ ./cmd/api ./cmd/worker",
+			startCmd: "./bin/api",
+			devCmd:   "go run ./cmd/api",
+		},
+		{
+			name:     "configured bins",
+			bins:     "worker",
+			buildCmd: "go build -ldflags=\"-w -s\" -o bin/ ./cmd/worker",
+			startCmd: "./bin/worker",
+			devCmd:   "go run ./cmd/worker",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContext(t, "../../../examples/go-multiple-bins")
+			if tt.bins != "" {
+				ctx.Env.SetVariable("RAILPACK_GO_BINS", tt.bins)
+			}
+
+			provider := GoProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+			require.NoError(t, provider.Plan(ctx))
+
+			build := (*ctx.GetStepByName("build")).(*generate.CommandStepBuilder)
+			require.Contains(t, build.Commands, plan.NewExecCommand(tt.buildCmd))
+			require.Equal(t, tt.startCmd, ctx.Deploy.StartCmd)
+			require.Contains(t, ctx.Deploy.Paths, "/app/bin")
+			require.Equal(t, tt.devCmd, provider.getDevStartCmd(ctx))
+		})
+	}
+}
+
+func TestGolangSelectStartBin(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/go-multiple-bins")
+	provider := GoProvider{}
+
+	require.Equal(t, "server", provider.selectStartBin(ctx, []string{"migrate", "server"}))
+	require.Empty(t, ctx.Logger.Logs)
+
+	require.Equal(t, "cron", provider.selectStartBin(ctx, []string{"cron", "migrate"}))
+	require.Len(t, ctx.Logger.Logs, 1)
+	require.Equal(t, logger.Warn, ctx.Logger.Logs[0].Level)
+}
+
+func TestGolangStaticBase(t *testing.T) {
+	tests := []struct {
+		name     string
+		envs     map[string]string
+		base     string
+		buildCmd string
+		noShell  bool
+	}{
+		{
+			name:     "default",
+			base:     plan.RailpackRuntimeImage,
+			buildCmd: "go build -ldflags=\"-w -s\" -o out",
+		},
+		{
+			name:     "distroless",
+			envs:     map[string]string{"RAILPACK_STATIC_BASE": "distroless"},
+			base:     plan.DistrolessStaticImage,
+			buildCmd: "go build -trimpath -ldflags=\"-w -s\" -o out",
+			noShell:  true,
+		},
+		{
+			name:     "scratch",
+			envs:     map[string]string{"RAILPACK_STATIC_BASE": "scratch"},
+			base:     plan.ScratchImage,
+			buildCmd: "go build -trimpath -tags timetzdata -ldflags=\"-w -s\" -o out",
+			noShell:  true,
+		},
+		{
+			name:     "runtime",
+			envs:     map[string]string{"RAILPACK_STATIC_BASE": "runtime"},
+			base:     plan.RailpackRuntimeImage,
+			buildCmd: "go build -ldflags=\"-w -s\" -o out",
+		},
+		{
+			name:     "cgo",
+			envs:     map[string]string{"CGO_ENABLED": "1", "RAILPACK_STATIC_BASE": "distroless"},
+			base:     plan.RailpackRuntimeImage,
+			buildCmd: "go build -ldflags=\"-w -s\" -o out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContext(t, "../../../examples/go-mod")
+			for name, value := range tt.envs {
+				ctx.Env.SetVariable(name, value)
+			}
+
+			provider := GoProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+			require.NoError(t, provider.Plan(ctx))
+
+			build := (*ctx.GetStepByName("build")).(*generate.CommandStepBuilder)
+			require.Contains(t, build.Commands, plan.NewExecCommand(tt.buildCmd))
+			require.Equal(t, tt.base, ctx.Deploy.Base.Image)
+			require.Equal(t, tt.noShell, ctx.Deploy.NoShell)
+
+			if tt.noShell {
+				require.Empty(t, ctx.Deploy.AptPackages)
+				require.Contains(t, ctx.Deploy.DeployInputs[0].Include, GO_BINARY_NAME)
+			}
+		})
+	}
+}
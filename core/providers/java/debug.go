@@ -0,0 +1,82 @@
+package java
+
+import (
+	"fmt"
+
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+const (
+	JDWP_PORT = "5005"
+
+	// Debuggers attach to the JDWP agent on all interfaces. The app starts without waiting for a debugger.
+	JDWP_AGENT = "-agentlib:jdwp=transport=dt_socket,server=y,suspend=n,address=*:" + JDWP_PORT
+
+	GradleDebugInitScriptPath    = "/gradle-debug.gradle"
+	gradleDebugInitScriptAssetID = "gradle-debug.gradle"
+
+	// bootRun is also a JavaExec task
+	gradleDebugInitScript = `allprojects {
+    tasks.withType(JavaExec).configureEach {
+        jvmArgs "%s"
+    }
+}
+`
+)
+
+// getDebugStartCmd returns the development start command with the JDWP agent loaded in the app's JVM.
+// Gradle and Maven run the app in a separate JVM that the agent cannot be passed to from the environment,
+// since JAVA_TOOL_OPTIONS would also load it in the Gradle JVMs and they would all listen on the same port.
+// Gradle loads it from an init script and Spring Boot's Maven plugin accepts the JVM arguments.
+// Other Maven apps run the built jar with the agent in JAVA_OPTS.
+func (p *JavaProvider) getDebugStartCmd(ctx *generate.GenerateContext) string {
+	if p.usesGradle(ctx) {
+		return fmt.Sprintf("%s %s --init-script %s", p.getGradleCmd(ctx), p.getGradleRunTask(ctx), GradleDebugInitScriptPath)
+	}
+
+	if p.usesSpringBootMaven(ctx) {
+		return fmt.Sprintf(`mvn spring-boot:run -Dspring-boot.run.jvmArguments="%s"`, JDWP_AGENT)
+	}
+
+	return p.getStartCmd(ctx)
+}
+
+// getDebugStartCmdHost returns the debug start command for the host, which does not have the init script.
+// Gradle's --debug-jvm listens on localhost and waits for a debugger to attach.
+func (p *JavaProvider) getDebugStartCmdHost(ctx *generate.GenerateContext) string {
+	if p.usesGradle(ctx) {
+		return fmt.Sprintf("%s %s --debug-jvm", p.getGradleCmd(ctx), p.getGradleRunTask(ctx))
+	}
+
+	return p.getDebugStartCmd(ctx)
+}
+
+// addGradleDebugInitScript writes the init script that loads the JDWP agent in the JVM of the run task
+func (p *JavaProvider) addGradleDebugInitScript(build *generate.CommandStepBuilder) {
+	build.Assets[gradleDebugInitScriptAssetID] = fmt.Sprintf(gradleDebugInitScript, JDWP_AGENT)
+	build.AddCommand(plan.NewFileCommand(GradleDebugInitScriptPath, gradleDebugInitScriptAssetID))
+}
+
+func (p *JavaProvider) getGradleRunTask(ctx *generate.GenerateContext) string {
+	if p.usesSpringBoot(ctx) {
+		return "bootRun"
+	}
+	return "run"
+}
+
+// usesDebugAgentEnv checks if the JDWP agent is passed to the app in JAVA_OPTS
+func (p *JavaProvider) usesDebugAgentEnv(ctx *generate.GenerateContext) bool {
+	return ctx.Debug && !p.usesGradle(ctx) && !p.usesSpringBootMaven(ctx)
+}
+
+func (p *JavaProvider) getGradleCmd(ctx *generate.GenerateContext) string {
+	if ctx.App.HasMatch("gradlew") {
+		return "./gradlew"
+	}
+	return "gradle"
+}
+
+func (p *JavaProvider) usesSpringBootMaven(ctx *generate.GenerateContext) bool {
+	return !p.usesGradle(ctx) && ctx.App.HasMatch("pom.xml") && p.usesSpringBoot(ctx)
+}
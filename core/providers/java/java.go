@@ -65,10 +65,16 @@ func (p *JavaProvider) Plan(ctx *generate.GenerateContext) error {
 		outPath = "."
 	}
 
+	include := []string{outPath}
+	if ctx.Debug && p.usesGradle(ctx) {
+		p.addGradleDebugInitScript(build)
+		include = append(include, GradleDebugInitScriptPath)
+	}
+
 	ctx.Deploy.AddInputs([]plan.Layer{
 		runtimeMiseStep.GetLayer(),
 		plan.NewStepLayer(build.Name(), plan.Filter{
-			Include: []string{outPath},
+			Include: include,
 		}),
 	})
 
@@ -85,8 +91,12 @@ func (p *JavaProvider) Plan(ctx *generate.GenerateContext) error {
 		// Add development environment variables
 		ctx.Deploy.Variables = p.getJavaDevEnvVars(ctx)
 		// Add required port for web applications
-		if port := p.getDevPort(ctx); port != "" {
-			ctx.Deploy.RequiredPort = port
+		ctx.SetRequiredPort(p.getDevPort(ctx))
+
+		if ctx.Debug {
+			ctx.Deploy.StartCmd = p.getDebugStartCmd(ctx)
+			ctx.Deploy.StartCmdHost = p.getDebugStartCmdHost(ctx)
+			ctx.AddDebugPort(JDWP_PORT)
 		}
 	} else {
 		// Add production environment variables
@@ -183,6 +193,11 @@ func (p *JavaProvider) getJavaDevEnvVars(ctx *generate.GenerateContext) map[stri
 		envVars["SPRING_JPA_PROPERTIES_HIBERNATE_FORMAT_SQL"] = "true"
 	}
 
+	// The JDWP agent is loaded by the JVM that runs the jar
+	if p.usesDebugAgentEnv(ctx) {
+		envVars["JAVA_OPTS"] = strings.TrimSpace(envVars["JAVA_OPTS"] + " " + JDWP_AGENT)
+	}
+
 	// Gradle-specific development settings
 	if p.usesGradle(ctx) {
 		envVars["GRADLE_OPTS"] = "-Xmx512m -Dfile.encoding=UTF-8"
@@ -228,8 +243,86 @@ func (p *JavaProvider) getJavaProdEnvVars(ctx *generate.GenerateContext) map[str
 	return envVars
 }
 
-// getDevPort returns the appropriate port for development mode based on framework
-func (p *JavaProvider) getDevPort(ctx *generate.GenerateContext) string {
+// getDevPort returns the port for development mode and where it was found.
+// The server.port in the Spring config is used if it is set, otherwise the framework default.
+func (p *JavaProvider) getDevPort(ctx *generate.GenerateContext) (string, string) {
+	if port, source := p.getSpringConfigPort(ctx); port != "" {
+		return port, source
+	}
+
+	return p.getDefaultDevPort(ctx), generate.PortSourceDefault
+}
+
+// getSpringConfigPort returns the server.port from the Spring Boot application.properties or application.yml
+func (p *JavaProvider) getSpringConfigPort(ctx *generate.GenerateContext) (string, string) {
+	files, err := ctx.App.FindFiles("**/src/main/resources/application.{properties,yml,yaml}")
+	if err != nil {
+		return "", ""
+	}
+
+	for _, file := range files {
+		var port string
+		if strings.HasSuffix(file, ".properties") {
+			port = p.getPropertiesPort(ctx, file)
+		} else {
+			port = p.getYamlPort(ctx, file)
+		}
+
+		if port != "" {
+			return port, file
+		}
+	}
+
+	return "", ""
+}
+
+func (p *JavaProvider) getPropertiesPort(ctx *generate.GenerateContext, file string) string {
+	contents, err := ctx.App.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		// Properties can be separated by = or :
+		index := strings.IndexAny(line, "=:")
+		if index == -1 {
+			continue
+		}
+
+		if strings.TrimSpace(line[:index]) == "server.port" {
+			return generate.ParsePort(line[index+1:])
+		}
+	}
+
+	return ""
+}
+
+func (p *JavaProvider) getYamlPort(ctx *generate.GenerateContext, file string) string {
+	var config map[string]interface{}
+	if err := ctx.App.ReadYAML(file, &config); err != nil {
+		return ""
+	}
+
+	if port, ok := config["server.port"]; ok {
+		return generate.ParsePort(fmt.Sprint(port))
+	}
+
+	if server, ok := config["server"].(map[interface{}]interface{}); ok {
+		if port, ok := server["port"]; ok {
+			return generate.ParsePort(fmt.Sprint(port))
+		}
+	}
+
+	return ""
+}
+
+// getDefaultDevPort returns the default port for development mode based on framework
+func (p *JavaProvider) getDefaultDevPort(ctx *generate.GenerateContext) string {
 	// Spring Boot applications
 	if p.usesSpringBoot(ctx) {
 		return "8080" // Spring Boot default port
@@ -3,6 +3,7 @@ package java
 import (
 	"testing"
 
+	"github.com/railwayapp/railpack/core/generate"
 	testingUtils "github.com/railwayapp/railpack/core/testing"
 	"github.com/stretchr/testify/require"
 )
@@ -121,3 +122,93 @@ func TestJava_Prod_SpringBoot_HasSpringProdVars(t *testing.T) {
 	require.Empty(t, ctx.Deploy.Variables["SPRING_DEVTOOLS_LIVERELOAD_ENABLED"])
 	require.Empty(t, ctx.Deploy.Variables["SPRING_JPA_SHOW_SQL"])
 }
+
+func TestJava_Dev_PortFromSpringConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		file           string
+		contents       string
+		expectedPort   string
+		expectedSource string
+	}{
+		{
+			name:           "properties",
+			file:           "application.properties",
+			contents:       "spring.application.name=demo\nserver.port=8081\n",
+			expectedPort:   "8081",
+			expectedSource: "src/main/resources/application.properties",
+		},
+		{
+			name:           "properties placeholder",
+			file:           "application.properties",
+			contents:       "server.port: ${PORT:9090}\n",
+			expectedPort:   "9090",
+			expectedSource: "src/main/resources/application.properties",
+		},
+		{
+			name:           "yaml",
+			file:           "application.yml",
+			contents:       "server:\n  port: 8082\n",
+			expectedPort:   "8082",
+			expectedSource: "src/main/resources/application.yml",
+		},
+		{
+			name:           "no port",
+			file:           "application.yml",
+			contents:       "spring:\n  application:\n    name: demo\n",
+			expectedPort:   "8080",
+			expectedSource: generate.PortSourceDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContextFromFiles(t, map[string]string{
+				"pom.xml":                       "<project></project>",
+				"src/main/resources/" + tt.file: tt.contents,
+			})
+			ctx.Dev = true
+
+			provider := JavaProvider{}
+			require.NoError(t, provider.Plan(ctx))
+
+			require.Equal(t, tt.expectedPort, ctx.Deploy.RequiredPort)
+			require.Equal(t, tt.expectedSource, ctx.Metadata.Get("requiredPortSource"))
+		})
+	}
+}
+
+func TestJava_Debug(t *testing.T) {
+	t.Run("gradle", func(t *testing.T) {
+		ctx := testingUtils.CreateGenerateContext(t, "../../../examples/java-gradle")
+		ctx.Dev = true
+		ctx.Debug = true
+
+		provider := JavaProvider{}
+		require.NoError(t, provider.Plan(ctx))
+
+		// The init script loads the JDWP agent in the JVM of the run task
+		require.Equal(t, "./gradlew run --init-script /gradle-debug.gradle", ctx.Deploy.StartCmd)
+		require.Equal(t, "./gradlew run --debug-jvm", ctx.Deploy.StartCmdHost)
+		require.Equal(t, "-Xmx512m -Xms256m", ctx.Deploy.Variables["JAVA_OPTS"])
+		require.Equal(t, "8080,"+JDWP_PORT, ctx.Deploy.RequiredPort)
+
+		build := (*ctx.GetStepByName("build")).(*generate.CommandStepBuilder)
+		require.Contains(t, build.Assets[gradleDebugInitScriptAssetID], `jvmArgs "-agentlib:jdwp=transport=dt_socket,server=y,suspend=n,address=*:5005"`)
+		require.Contains(t, ctx.Deploy.DeployInputs[1].Include, GradleDebugInitScriptPath)
+	})
+
+	t.Run("maven", func(t *testing.T) {
+		ctx := testingUtils.CreateGenerateContext(t, "../../../examples/java-maven")
+		ctx.Dev = true
+		ctx.Debug = true
+
+		provider := JavaProvider{}
+		require.NoError(t, provider.Plan(ctx))
+
+		// The built jar is run with the JDWP agent
+		require.Contains(t, ctx.Deploy.StartCmd, "-jar")
+		require.Equal(t, "-Xmx512m -Xms256m "+JDWP_AGENT, ctx.Deploy.Variables["JAVA_OPTS"])
+		require.Equal(t, "8080,"+JDWP_PORT, ctx.Deploy.RequiredPort)
+	})
+}
@@ -3,6 +3,7 @@ package node
 import (
 	"encoding/json"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/railwayapp/railpack/core/generate"
@@ -30,6 +31,11 @@ type AngularConfig struct {
 					Browser    string `json:"browser,omitempty"`
 				} `json:"options"`
 			} `json:"build"`
+			Serve struct {
+				Options struct {
+					Port int `json:"port,omitempty"`
+				} `json:"options"`
+			} `json:"serve"`
 		} `json:"architect"`
 	} `json:"projects"`
 }
@@ -68,3 +74,25 @@ func (p *NodeProvider) getAngularOutputDirectory(ctx *generate.GenerateContext)
 
 	return ""
 }
+
+// getAngularConfigPort returns the port of the serve target in angular.json
+func (p *NodeProvider) getAngularConfigPort(ctx *generate.GenerateContext) string {
+	var config AngularConfig
+	if err := ctx.App.ReadJSON("angular.json", &config); err != nil {
+		return ""
+	}
+
+	projectName, _ := ctx.Env.GetConfigVariable("ANGULAR_PROJECT")
+
+	for name, project := range config.Projects {
+		if projectName != "" && projectName != name {
+			continue
+		}
+
+		if port := project.Architect.Serve.Options.Port; port != 0 {
+			return strconv.Itoa(port)
+		}
+	}
+
+	return ""
+}
@@ -80,3 +80,8 @@ func (p *NodeProvider) getAstroEnvVars() map[string]string {
 
 	return envVars
 }
+
+// getAstroConfigPort returns the server.port set in the astro config
+func (p *NodeProvider) getAstroConfigPort(ctx *generate.GenerateContext) (string, string) {
+	return p.getConfigServerPort(ctx, "astro.config.{js,ts,mjs,mts}")
+}
@@ -0,0 +1,127 @@
+package node
+
+import (
+	"path"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+const (
+	BUN_BINARY_NAME = "out"
+	BUN_COMPILE_VAR = "BUN_COMPILE"
+)
+
+var (
+	// bunEntrypoints are the files checked for the entry of a Bun app, in order of priority
+	bunEntrypoints = []string{
+		"src/index.ts", "src/index.tsx", "src/index.js",
+		"src/server.ts", "src/server.js",
+		"src/main.ts", "src/main.js",
+		"index.ts", "index.tsx", "index.js",
+		"server.ts", "server.js",
+		"main.ts", "main.js",
+	}
+)
+
+// usesBunRuntime checks if the app runs on Bun rather than Node
+func (p *NodeProvider) usesBunRuntime() bool {
+	if p.packageJson == nil {
+		return false
+	}
+
+	if p.packageManager == PackageManagerBun || p.hasDependency("@types/bun") || p.hasDependency("bun-types") {
+		return true
+	}
+
+	_, hasBunEngine := p.packageJson.Engines["bun"]
+	return hasBunEngine
+}
+
+// getBunEntrypoint returns the entry file of a Bun app.
+// A file that starts a server with Bun.serve is preferred over the other candidates.
+func (p *NodeProvider) getBunEntrypoint(ctx *generate.GenerateContext) string {
+	if !p.usesBunRuntime() {
+		return ""
+	}
+
+	entrypoint := ""
+	for _, name := range bunEntrypoints {
+		file := path.Join(p.packageDir(), name)
+		if !ctx.App.HasFile(file) {
+			continue
+		}
+
+		if contents, err := ctx.App.ReadFile(file); err == nil && strings.Contains(contents, "Bun.serve") {
+			return file
+		}
+
+		if entrypoint == "" {
+			entrypoint = file
+		}
+	}
+
+	return entrypoint
+}
+
+// getBunStartCommand returns the command to run the entry file of a Bun app
+func (p *NodeProvider) getBunStartCommand(ctx *generate.GenerateContext) string {
+	if entrypoint := p.getBunEntrypoint(ctx); entrypoint != "" {
+		return "bun run " + entrypoint
+	}
+
+	return ""
+}
+
+// getBunDevStartCommand runs the entry file with hot reloading when there is no dev script
+func (p *NodeProvider) getBunDevStartCommand(ctx *generate.GenerateContext) string {
+	if entrypoint := p.getBunEntrypoint(ctx); entrypoint != "" {
+		return "bun --hot " + entrypoint
+	}
+
+	return ""
+}
+
+// getBunCompileEntrypoint returns the file to compile into a single binary. Compiling is opt-in.
+func (p *NodeProvider) getBunCompileEntrypoint(ctx *generate.GenerateContext) string {
+	if ctx.Dev || !p.usesBunRuntime() || !ctx.Env.IsConfigVariableTruthy(BUN_COMPILE_VAR) {
+		return ""
+	}
+
+	entrypoint := p.getBunEntrypoint(ctx)
+	if main := p.packageJson.Main; entrypoint == "" && main != "" && p.workspacePackage == nil {
+		entrypoint = main
+	}
+
+	if entrypoint == "" {
+		ctx.Logger.LogWarn("Could not find an entrypoint to compile with Bun, deploying the full app")
+		return ""
+	}
+
+	return entrypoint
+}
+
+// DeployBunCompile compiles the app with `bun build --compile` and only deploys the resulting binary.
+// The binary includes the Bun runtime, so neither Bun nor node_modules are needed in the final image.
+// With RAILPACK_STATIC_BASE it is deployed on a minimal glibc image instead of the runtime image.
+func (p *NodeProvider) DeployBunCompile(ctx *generate.GenerateContext, build *generate.CommandStepBuilder, entrypoint string) {
+	ctx.Logger.LogInfo("Compiling %s into a single binary with Bun", entrypoint)
+
+	build.AddCommand(plan.NewExecCommand("bun build --compile --minify --sourcemap " + entrypoint + " --outfile " + BUN_BINARY_NAME))
+
+	ctx.Deploy.StartCmd = "./" + BUN_BINARY_NAME
+
+	// The binary is linked against glibc, so it needs the cc image even when scratch is configured
+	if base := ctx.GetStaticBase(); base != "" && len(ctx.Deploy.AptPackages) == 0 {
+		ctx.Logger.LogInfo("Deploying on a distroless cc base image")
+		ctx.Deploy.UseGlibcBase(build.Name(), []string{BUN_BINARY_NAME})
+		return
+	}
+
+	ctx.Deploy.AddInputs([]plan.Layer{
+		plan.NewStepLayer(build.Name(), plan.Filter{
+			Include: []string{BUN_BINARY_NAME},
+		}),
+	})
+}
@@ -0,0 +1,111 @@
+package node
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+const NODE_INSPECT_PORT = "9229"
+
+// Launchers that run the app in a child process. The inspector flag is passed to the launcher,
+// which forwards it to the app, since NODE_OPTIONS would be inherited by both processes
+var inspectLaunchers = []string{"node", "nodemon", "ts-node-dev", "tsx"}
+
+// getDebugStartCommand returns the development start command with the inspector listening on all interfaces.
+// The second command also binds the dev server to the host.
+func (p *NodeProvider) getDebugStartCommand(ctx *generate.GenerateContext) (string, string) {
+	inspect := "--inspect=0.0.0.0:" + NODE_INSPECT_PORT
+
+	packageJson := p.packageJson
+	if p.workspacePackage != nil {
+		packageJson = p.workspacePackage.PackageJson
+	}
+
+	scriptName, _ := p.getPreferredDevScriptName(ctx)
+	if scriptName == "" {
+		scriptName = "start"
+	}
+
+	if packageJson != nil {
+		if script := p.getScripts(packageJson, scriptName); script != "" {
+			hostFlag := p.getHostBindingFlag(ctx, script)
+			if p.hasExistingHostBinding(script) {
+				hostFlag = ""
+			}
+
+			if launch := injectInspectFlag(script, inspect); launch != "" {
+				cmd := launch
+				if !strings.HasPrefix(launch, "node ") {
+					// Binaries in node_modules/.bin are not on the PATH outside of the package manager
+					cmd = p.packageManager.ExecCmd(launch)
+				}
+				if dir := p.packageDir(); dir != "" {
+					cmd = fmt.Sprintf("cd %s && %s", dir, cmd)
+				}
+
+				hostCmd := cmd
+				if hostFlag != "" {
+					hostCmd = cmd + " " + hostFlag
+				}
+				return cmd, hostCmd
+			}
+
+			// The package manager is also a Node.js process and must not take the inspector port
+			var env string
+			switch p.packageManager {
+			case PackageManagerNpm, PackageManagerPnpm:
+				// Passed to the script as NODE_OPTIONS, but not used by the package manager itself
+				env = "npm_config_node_options=" + inspect
+			case PackageManagerBun:
+				env = "NODE_OPTIONS=" + inspect
+			default:
+				ctx.Logger.LogWarn("Cannot attach the inspector to the %s script with %s. Add `%s` to the script to debug it", scriptName, p.packageManager.Name(), inspect)
+				return "", ""
+			}
+
+			cmd := env + " " + p.getRunBase(scriptName)
+			hostCmd := cmd
+			if hostFlag != "" {
+				hostCmd = env + " " + p.getHostRunCommand(ctx, scriptName, hostFlag)
+			}
+			return cmd, hostCmd
+		}
+	}
+
+	if entrypoint := p.getBunEntrypoint(ctx); entrypoint != "" {
+		cmd := fmt.Sprintf("bun --hot %s %s", inspect, entrypoint)
+		return cmd, cmd
+	}
+
+	if start := p.GetStartCommand(ctx); strings.HasPrefix(start, "node ") {
+		cmd := fmt.Sprintf("node %s %s", inspect, strings.TrimPrefix(start, "node "))
+		return cmd, cmd
+	}
+
+	return "", ""
+}
+
+// injectInspectFlag adds the inspect flag after the launcher of a script that only runs a launcher.
+// It returns an empty string for other scripts.
+func injectInspectFlag(script string, inspect string) string {
+	if plan.CommandNeedsShell(script) || strings.Contains(script, "--inspect") {
+		return ""
+	}
+
+	fields := strings.Fields(script)
+	if len(fields) < 2 || !slices.Contains(inspectLaunchers, fields[0]) {
+		return ""
+	}
+
+	// tsx takes the flag after its watch subcommand
+	args := 1
+	if fields[0] == "tsx" && fields[1] == "watch" {
+		args = 2
+	}
+
+	return strings.Join(slices.Concat(fields[:args], []string{inspect}, fields[args:]), " ")
+}
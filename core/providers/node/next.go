@@ -0,0 +1,86 @@
+package node
+
+import (
+	"path"
+	"regexp"
+
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+var (
+	// nextStandaloneRegex matches `output: "standalone"` in a Next config file
+	nextStandaloneRegex = regexp.MustCompile("output\\s*:\\s*[\"'`]standalone[\"'`]")
+)
+
+// isNextStandalone checks if the Next config sets the standalone output mode
+func (p *NodeProvider) isNextStandalone(ctx *generate.GenerateContext) bool {
+	if !p.isNext() {
+		return false
+	}
+
+	files, err := ctx.App.FindFiles(path.Join(p.packageDir(), "next.config.{js,mjs,cjs,ts,mts}"))
+	if err != nil {
+		return false
+	}
+
+	for _, file := range files {
+		contents, err := ctx.App.ReadFile(file)
+		if err == nil && nextStandaloneRegex.MatchString(contents) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shouldDeployNextStandalone checks if only the standalone Next server should be deployed.
+// A custom start command may depend on the full app, so the regular deploy is used for it.
+func (p *NodeProvider) shouldDeployNextStandalone(ctx *generate.GenerateContext) bool {
+	if ctx.Dev || !p.isNext() {
+		return false
+	}
+
+	if ctx.Config.Deploy != nil && ctx.Config.Deploy.StartCmd != "" {
+		return false
+	}
+
+	if !p.isNextStandalone(ctx) {
+		ctx.Logger.LogWarn("Next.js standalone output is not enabled, so all node_modules are deployed")
+		ctx.Logger.LogWarn("Set `output: \"standalone\"` in your next.config to reduce the image size")
+		return false
+	}
+
+	return true
+}
+
+// DeployNextStandalone deploys the minimal server that Next traces into .next/standalone.
+// The standalone server expects the static assets and public directory next to it, so they are
+// copied into place in a separate step and only the result is deployed.
+func (p *NodeProvider) DeployNextStandalone(ctx *generate.GenerateContext, miseStep *generate.MiseStepBuilder, build *generate.CommandStepBuilder) {
+	appDir := p.packageDir()
+	standaloneDir := path.Join(appDir, ".next/standalone")
+
+	ctx.Logger.LogInfo("Deploying Next.js standalone output from %s", standaloneDir)
+
+	include := []string{standaloneDir, path.Join(appDir, ".next/static")}
+	if ctx.App.HasMatch(path.Join(appDir, "public")) {
+		include = append(include, path.Join(appDir, "public"))
+	}
+
+	standalone := ctx.NewCommandStep("next-standalone")
+	standalone.AddInput(plan.NewStepLayer(miseStep.Name()))
+	standalone.AddInput(plan.NewStepLayer(build.Name(), plan.Filter{Include: include}))
+	standalone.Secrets = []string{}
+
+	// The standalone directory mirrors the app root, so the server of a workspace package is in the package directory
+	standalone.AddCommand(plan.NewExecShellCommand("cp -a " + standaloneDir + "/. . && rm -rf " + standaloneDir))
+
+	ctx.Deploy.StartCmd = p.packageManager.RunScriptCommand(path.Join(appDir, "server.js"))
+	ctx.Deploy.Variables["HOSTNAME"] = "0.0.0.0"
+
+	ctx.Deploy.AddInputs([]plan.Layer{
+		miseStep.GetLayer(),
+		plan.NewStepLayer(standalone.Name(), plan.Filter{Include: []string{"."}}),
+	})
+}
@@ -0,0 +1,103 @@
+package node
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/generate"
+)
+
+// Nuxt and SolidStart build with Nitro, which outputs a server or a static site depending on the preset
+
+const (
+	DefaultNitroOutputDirectory = ".output"
+	NitroPresetVar              = "NITRO_PRESET"
+)
+
+var (
+	// nitroPresetRegex matches the preset option in a Nuxt or SolidStart config (e.g. nitro: { preset: 'bun' })
+	nitroPresetRegex = regexp.MustCompile(`preset\s*:\s*['"]([a-z0-9_-]+)['"]`)
+
+	nitroServerPresets = []string{"node-server", "node", "node-cluster", "bun"}
+	nitroStaticPresets = []string{"static", "github-pages", "gitlab-pages"}
+)
+
+func (p *NodeProvider) isSolidStart() bool {
+	return p.hasDependency("@solidjs/start")
+}
+
+func (p *NodeProvider) isNitro() bool {
+	return p.isNuxt() || p.isSolidStart()
+}
+
+// getNitroPreset returns the Nitro preset from the NITRO_PRESET variable or the framework config.
+// An empty preset means the default node-server preset.
+func (p *NodeProvider) getNitroPreset(ctx *generate.GenerateContext) string {
+	if preset := ctx.Env.GetVariable(NitroPresetVar); preset != "" {
+		return strings.ReplaceAll(preset, "_", "-")
+	}
+
+	configFiles := "app.config.{js,ts,mjs}"
+	if p.isNuxt() {
+		configFiles = "nuxt.config.{js,ts,mjs}"
+	}
+
+	files, err := ctx.App.FindFiles(path.Join(p.packageDir(), configFiles))
+	if err != nil {
+		return ""
+	}
+
+	for _, file := range files {
+		contents, err := ctx.App.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		if matches := nitroPresetRegex.FindStringSubmatch(contents); matches != nil {
+			return strings.ReplaceAll(matches[1], "_", "-")
+		}
+	}
+
+	return ""
+}
+
+// isNitroStatic checks if Nitro generates a static site, either with a static preset or with `nuxt generate`
+func (p *NodeProvider) isNitroStatic(ctx *generate.GenerateContext) bool {
+	if !p.isNitro() {
+		return false
+	}
+
+	if slices.Contains(nitroStaticPresets, p.getNitroPreset(ctx)) {
+		return true
+	}
+
+	packageJson := p.packageJson
+	if p.workspacePackage != nil {
+		packageJson = p.workspacePackage.PackageJson
+	}
+
+	return p.isNuxt() && strings.Contains(packageJson.GetScript("build"), "nuxt generate")
+}
+
+func (p *NodeProvider) getNitroOutputDirectory(ctx *generate.GenerateContext) string {
+	return path.Join(p.packageDir(), DefaultNitroOutputDirectory, "public")
+}
+
+// getNitroStartCommand returns the command to start the server built by one of the Node compatible presets
+func (p *NodeProvider) getNitroStartCommand(ctx *generate.GenerateContext) string {
+	preset := p.getNitroPreset(ctx)
+	server := path.Join(p.packageDir(), DefaultNitroOutputDirectory, "server/index.mjs")
+
+	if preset == "bun" {
+		return "bun " + server
+	} else if preset == "" || slices.Contains(nitroServerPresets, preset) {
+		return p.packageManager.RunScriptCommand(server)
+	}
+
+	ctx.Logger.LogWarn("Nitro preset `%s` does not build a server that can be started with Node", preset)
+	ctx.Logger.LogWarn("Use the node-server preset for a server or the static preset for a static site")
+
+	return ""
+}
@@ -5,9 +5,11 @@ import (
 	"maps"
 	"path"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/railwayapp/railpack/core/app"
+	"github.com/railwayapp/railpack/core/config"
 	"github.com/railwayapp/railpack/core/generate"
 	"github.com/railwayapp/railpack/core/plan"
 )
@@ -25,12 +27,21 @@ const (
 var (
 	// bunCommandRegex matches "bun" or "bunx" as a command (not part of another word)
 	bunCommandRegex = regexp.MustCompile(`(^|\s|;|&|&&|\||\|\|)bunx?\s`)
+
+	// serverPortRegex matches the port in the server options of a JS config file
+	serverPortRegex = regexp.MustCompile(`server\s*:\s*\{[^{}]*?\bport\s*:\s*(\d+)`)
 )
 
 type NodeProvider struct {
 	packageJson    *PackageJson
 	packageManager PackageManager
 	workspace      *Workspace
+
+	// The workspace package to build and deploy. If nil, the whole app is built.
+	workspacePackage *WorkspacePackage
+
+	// The step with the manifests pruned by turbo. If set, dependencies are installed from it.
+	turboPruneStep string
 }
 
 func (p *NodeProvider) Name() string {
@@ -52,6 +63,10 @@ func (p *NodeProvider) Initialize(ctx *generate.GenerateContext) error {
 	}
 	p.workspace = workspace
 
+	if workspaceName := p.getWorkspaceName(ctx); workspaceName != "" {
+		p.workspacePackage = workspace.FindPackage(workspaceName)
+	}
+
 	return nil
 }
 
@@ -64,6 +79,10 @@ func (p *NodeProvider) Plan(ctx *generate.GenerateContext) error {
 		return fmt.Errorf("package.json not found")
 	}
 
+	if workspaceName := p.getWorkspaceName(ctx); workspaceName != "" && p.workspacePackage == nil {
+		return fmt.Errorf("workspace package `%s` not found. Available packages: %s", workspaceName, strings.Join(p.workspacePackagePaths(), ", "))
+	}
+
 	p.SetNodeMetadata(ctx)
 
 	ctx.Logger.LogInfo("Using %s package manager", p.packageManager)
@@ -72,11 +91,23 @@ func (p *NodeProvider) Plan(ctx *generate.GenerateContext) error {
 		ctx.Logger.LogInfo("Found workspace with %d packages", len(p.workspace.Packages))
 	}
 
+	if p.workspacePackage != nil {
+		ctx.Logger.LogInfo("Building workspace package %s", p.workspacePackage.Path)
+	}
+
 	isSPA := p.isSPA(ctx)
 
 	miseStep := ctx.GetMiseStepBuilder()
 	p.InstallMisePackages(ctx, miseStep)
 
+	systemPackages := ctx.GetSystemPackages("node", nodeSystemPackages, p.workspace.HasDependency)
+	miseStep.SupportingAptPackages = append(miseStep.SupportingAptPackages, systemPackages.Build...)
+
+	if p.shouldTurboPrune(ctx) {
+		ctx.Logger.LogInfo("Pruning workspace to %s with turbo", p.workspacePackage.Path)
+		p.turboPruneStep = p.TurboPrune(ctx, miseStep).Name()
+	}
+
 	// Install
 	install := ctx.NewCommandStep("install")
 	install.AddInput(plan.NewStepLayer(miseStep.Name()))
@@ -120,6 +151,9 @@ func (p *NodeProvider) Plan(ctx *generate.GenerateContext) error {
 					// No special host flag; still provide a host command baseline
 					ctx.Deploy.StartCmdHost = p.getRunBase(scriptName)
 				}
+			} else {
+				// Bun apps without a dev script run the entrypoint directly
+				ctx.Deploy.StartCmdHost = devCmd
 			}
 		} else {
 			// Some frameworks (e.g., Angular) use the start script for dev
@@ -137,36 +171,55 @@ func (p *NodeProvider) Plan(ctx *generate.GenerateContext) error {
 		}
 
 		// Set required port for development
-		if devPort := p.getDevPort(ctx); devPort != "" {
-			ctx.Deploy.RequiredPort = devPort
+		ctx.SetRequiredPort(p.getDevPort(ctx))
+
+		if ctx.Debug {
+			if debugCmd, debugHostCmd := p.getDebugStartCommand(ctx); debugCmd != "" {
+				ctx.Deploy.StartCmd = debugCmd
+				ctx.Deploy.StartCmdHost = debugHostCmd
+				ctx.AddDebugPort(NODE_INSPECT_PORT)
+			}
 		}
 	}
 
 	// Custom deploy for SPA's (production only). In dev, run the dev server instead.
 	if isSPA && !ctx.Dev {
+		// Static files are served by Caddy, so the runtime packages are not needed
 		err := p.DeploySPA(ctx, build)
 		return err
 	}
 
+	ctx.Deploy.AddAptPackages(systemPackages.Runtime)
+
+	if p.shouldDeployNextStandalone(ctx) {
+		p.DeployNextStandalone(ctx, miseStep, build)
+		return nil
+	}
+
+	if entrypoint := p.getBunCompileEntrypoint(ctx); entrypoint != "" {
+		p.DeployBunCompile(ctx, build, entrypoint)
+		return nil
+	}
+
 	// All the files we need to include in the deploy
 	buildIncludeDirs := []string{"/root/.cache", "."}
 
+	if p.workspacePackage != nil {
+		buildIncludeDirs = append([]string{"/root/.cache"}, p.workspaceDeployPaths(ctx)...)
+	}
+
 	if p.usesCorepack() {
 		buildIncludeDirs = append(buildIncludeDirs, COREPACK_HOME)
 	}
 
-	runtimeAptPackages := []string{}
-	if p.usesPuppeteer() {
-		ctx.Logger.LogInfo("Installing puppeteer dependencies")
-		runtimeAptPackages = append(runtimeAptPackages, "xvfb", "gconf-service", "libasound2", "libatk1.0-0", "libc6", "libcairo2", "libcups2", "libdbus-1-3", "libexpat1", "libfontconfig1", "libgbm1", "libgcc1", "libgconf-2-4", "libgdk-pixbuf2.0-0", "libglib2.0-0", "libgtk-3-0", "libnspr4", "libpango-1.0-0", "libpangocairo-1.0-0", "libstdc++6", "libx11-6", "libx11-xcb1", "libxcb1", "libxcomposite1", "libxcursor1", "libxdamage1", "libxext6", "libxfixes3", "libxi6", "libxrandr2", "libxrender1", "libxss1", "libxtst6", "ca-certificates", "fonts-liberation", "libappindicator1", "libnss3", "lsb-release", "xdg-utils", "wget")
-	}
+	installFolders := append(p.packageManager.GetInstallFolder(ctx), p.workspaceNodeModules(ctx)...)
 
 	nodeModulesLayer := plan.NewStepLayer(build.Name(), plan.Filter{
-		Include: p.packageManager.GetInstallFolder(ctx),
+		Include: installFolders,
 	})
 	if p.shouldPrune(ctx) {
 		nodeModulesLayer = plan.NewStepLayer(prune.Name(), plan.Filter{
-			Include: p.packageManager.GetInstallFolder(ctx),
+			Include: installFolders,
 		})
 	}
 
@@ -175,7 +228,6 @@ func (p *NodeProvider) Plan(ctx *generate.GenerateContext) error {
 		Exclude: []string{"node_modules", ".yarn"},
 	})
 
-	ctx.Deploy.AddAptPackages(runtimeAptPackages)
 	ctx.Deploy.AddInputs([]plan.Layer{
 		miseStep.GetLayer(),
 		nodeModulesLayer,
@@ -199,15 +251,33 @@ func (p *NodeProvider) StartCommandHelp() string {
 }
 
 func (p *NodeProvider) GetStartCommand(ctx *generate.GenerateContext) string {
+	if p.workspacePackage != nil {
+		return p.getWorkspaceStartCommand(ctx)
+	}
+
 	if start := p.getScripts(p.packageJson, "start"); start != "" {
 		return p.packageManager.RunCmd("start")
+	} else if frameworkStart := p.getFrameworkStartCommand(ctx); frameworkStart != "" {
+		return frameworkStart
 	} else if main := p.packageJson.Main; main != "" {
 		return p.packageManager.RunScriptCommand(main)
+	} else if bunStart := p.getBunStartCommand(ctx); bunStart != "" {
+		return bunStart
 	} else if files, err := ctx.App.FindFiles("{index.js,index.ts}"); err == nil && len(files) > 0 {
 		return p.packageManager.RunScriptCommand(files[0])
-	} else if p.isNuxt() {
-		// Default Nuxt start command
-		return "node .output/server/index.mjs"
+	}
+
+	return ""
+}
+
+// getFrameworkStartCommand returns the command to start the server built by a full-stack framework adapter
+func (p *NodeProvider) getFrameworkStartCommand(ctx *generate.GenerateContext) string {
+	if p.isSvelteKit() {
+		return p.getSvelteKitStartCommand(ctx)
+	} else if p.isNitro() {
+		return p.getNitroStartCommand(ctx)
+	} else if p.isRemix() {
+		return p.getRemixStartCommand(ctx)
 	}
 
 	return ""
@@ -223,7 +293,7 @@ func (p *NodeProvider) GetDevStartCommand(ctx *generate.GenerateContext) string
 	if scriptName, _ := p.getPreferredDevScriptName(ctx); scriptName != "" {
 		return p.getRunBase(scriptName)
 	}
-	return ""
+	return p.getBunDevStartCommand(ctx)
 }
 
 // getDevStartArgsSuffix returns additional CLI args to append to the package manager
@@ -274,7 +344,12 @@ func (p *NodeProvider) getHostBindingFlag(ctx *generate.GenerateContext, devScri
 // getPreferredDevScriptName returns the best-matching dev script name and its command
 // by checking common dev aliases in priority order.
 func (p *NodeProvider) getPreferredDevScriptName(ctx *generate.GenerateContext) (string, string) {
-	if p.packageJson == nil || p.packageJson.Scripts == nil {
+	packageJson := p.packageJson
+	if p.workspacePackage != nil {
+		packageJson = p.workspacePackage.PackageJson
+	}
+
+	if packageJson == nil || packageJson.Scripts == nil {
 		return "", ""
 	}
 	if p.isAngular(ctx) {
@@ -295,7 +370,7 @@ func (p *NodeProvider) getPreferredDevScriptName(ctx *generate.GenerateContext)
 	}
 
 	for _, name := range candidates {
-		if val, ok := p.packageJson.Scripts[name]; ok && strings.TrimSpace(val) != "" {
+		if val, ok := packageJson.Scripts[name]; ok && strings.TrimSpace(val) != "" {
 			return name, val
 		}
 	}
@@ -303,8 +378,64 @@ func (p *NodeProvider) getPreferredDevScriptName(ctx *generate.GenerateContext)
 	return "", ""
 }
 
-// getDevPort returns the default development port for the detected framework
-func (p *NodeProvider) getDevPort(ctx *generate.GenerateContext) string {
+// getDevPort returns the development port and where it was found.
+// The port is read from the dev script flags and framework config files, falling back to the framework default.
+func (p *NodeProvider) getDevPort(ctx *generate.GenerateContext) (string, string) {
+	if scriptName, script := p.getPreferredDevScriptName(ctx); script != "" {
+		if port := generate.PortFromCommand(script); port != "" {
+			return port, path.Join(p.packageDir(), "package.json") + " > scripts > " + scriptName
+		}
+	}
+
+	if port, source := p.getFrameworkConfigPort(ctx); port != "" {
+		return port, source
+	}
+
+	return p.getDefaultDevPort(ctx), generate.PortSourceDefault
+}
+
+// getFrameworkConfigPort returns the dev server port set in the config file of the detected framework
+func (p *NodeProvider) getFrameworkConfigPort(ctx *generate.GenerateContext) (string, string) {
+	if p.isAngular(ctx) {
+		if port := p.getAngularConfigPort(ctx); port != "" {
+			return port, "angular.json"
+		}
+	}
+
+	if p.isAstro(ctx) {
+		return p.getAstroConfigPort(ctx)
+	}
+
+	if p.isVite(ctx) || p.isReactRouter(ctx) {
+		return p.getViteConfigPort(ctx)
+	}
+
+	return "", ""
+}
+
+// getConfigServerPort returns the port in the `server` options of a JS config file (e.g. server: { port: 3000 })
+func (p *NodeProvider) getConfigServerPort(ctx *generate.GenerateContext, pattern string) (string, string) {
+	files, err := ctx.App.FindFiles(path.Join(p.packageDir(), pattern))
+	if err != nil {
+		return "", ""
+	}
+
+	for _, file := range files {
+		contents, err := ctx.App.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		if matches := serverPortRegex.FindStringSubmatch(contents); matches != nil {
+			return matches[1], file
+		}
+	}
+
+	return "", ""
+}
+
+// getDefaultDevPort returns the default development port for the detected framework
+func (p *NodeProvider) getDefaultDevPort(ctx *generate.GenerateContext) string {
 	// Check for SPA frameworks first
 	if p.isSPA(ctx) {
 		if p.isAngular(ctx) {
@@ -327,6 +458,10 @@ func (p *NodeProvider) getDevPort(ctx *generate.GenerateContext) string {
 		return "3000" // Remix default port
 	} else if p.isTanstackStart() {
 		return "3000" // Tanstack Start default port
+	} else if p.isSolidStart() {
+		return "3000" // SolidStart default port
+	} else if p.isSvelteKit() {
+		return "5173" // SvelteKit uses the Vite dev server
 	} else if p.isVite(ctx) {
 		return "5173" // Vite default port
 	}
@@ -347,6 +482,10 @@ func (p *NodeProvider) getHostRunCommand(ctx *generate.GenerateContext, scriptNa
 
 // getRunBase returns the base run command per package manager for a script
 func (p *NodeProvider) getRunBase(scriptName string) string {
+	if p.workspacePackage != nil {
+		return p.packageManager.WorkspaceRunCmd(p.workspacePackage, scriptName)
+	}
+
 	switch p.packageManager {
 	case PackageManagerNpm:
 		if scriptName == "start" {
@@ -385,6 +524,12 @@ func (p *NodeProvider) getPMArgsSeparator() string {
 func (p *NodeProvider) Build(ctx *generate.GenerateContext, build *generate.CommandStepBuilder) {
 	build.AddInput(plan.NewLocalLayer())
 
+	if p.workspacePackage != nil {
+		p.buildWorkspacePackage(ctx, build)
+		p.addCaches(ctx, build)
+		return
+	}
+
 	_, ok := p.packageJson.Scripts["build"]
 	if ok {
 		build.AddCommands([]plan.Command{
@@ -394,8 +539,13 @@ func (p *NodeProvider) Build(ctx *generate.GenerateContext, build *generate.Comm
 		if p.isNext() {
 			build.AddVariables(map[string]string{"NEXT_TELEMETRY_DISABLED": "1"})
 		}
+	} else if p.isTurbo(ctx) {
+		build.AddCommand(plan.NewExecCommand(p.turboBuildCommand()))
+	} else if p.isNx(ctx) {
+		build.AddCommand(plan.NewExecCommand(p.nxBuildCommand(ctx)))
 	}
 
+	p.addMonorepoVariables(ctx, build)
 	p.addCaches(ctx, build)
 }
 
@@ -417,6 +567,13 @@ func (p *NodeProvider) addFrameworkCaches(ctx *generate.GenerateContext, build *
 func (p *NodeProvider) addCaches(ctx *generate.GenerateContext, build *generate.CommandStepBuilder) {
 	build.AddCache(ctx.Caches.AddCache("node-modules", "/app/node_modules/.cache"))
 
+	if p.isTurbo(ctx) {
+		build.AddCache(ctx.Caches.AddCache("turbo", TURBO_CACHE_DIR))
+	}
+	if p.isNx(ctx) {
+		build.AddCache(ctx.Caches.AddCache("nx", NX_CACHE_DIR))
+	}
+
 	p.addFrameworkCaches(ctx, build, "next", func(pkg *WorkspacePackage, ctx *generate.GenerateContext) bool {
 		if pkg.PackageJson.HasScript("build") {
 			return strings.Contains(pkg.PackageJson.Scripts["build"], "next build")
@@ -479,10 +636,19 @@ func (p *NodeProvider) InstallNodeDeps(ctx *generate.GenerateContext, install *g
 		// it's possible for a package.json to exist without any dependencies, in which case node_modules is not generated
 		// and bun.lockb, etc are not generated either. However, this path is used to compute the cache key, so we ensure
 		// it exists on the filesystem to avoid a docker cache key computation error.
-		plan.NewExecCommand(fmt.Sprintf("mkdir -p %s", NODE_MODULES_CACHE)),
+		plan.NewExecCommand(fmt.Sprintf("mkdir -p %s", strings.Join(append([]string{NODE_MODULES_CACHE}, p.workspaceNodeModules(ctx)...), " "))),
 	})
 
-	p.packageManager.installDependencies(ctx, p.workspace, install)
+	if p.turboPruneStep != "" {
+		install.AddInput(plan.NewStepLayer(p.turboPruneStep, plan.Filter{Include: []string{"."}}))
+		for _, file := range p.packageManager.SupportingConfigFiles(ctx) {
+			install.AddCommand(plan.NewCopyCommand(file, file))
+		}
+		p.packageManager.installDeps(ctx, p.workspacePackage, install)
+		return
+	}
+
+	p.packageManager.installDependencies(ctx, p.workspace, p.workspacePackage, install)
 }
 
 func (p *NodeProvider) InstallNodeDepsDev(ctx *generate.GenerateContext, install *generate.CommandStepBuilder) {
@@ -512,10 +678,10 @@ func (p *NodeProvider) InstallNodeDepsDev(ctx *generate.GenerateContext, install
 		// it's possible for a package.json to exist without any dependencies, in which case node_modules is not generated
 		// and bun.lockb, etc are not generated either. However, this path is used to compute the cache key, so we ensure
 		// it exists on the filesystem to avoid a docker cache key computation error.
-		plan.NewExecCommand(fmt.Sprintf("mkdir -p %s", NODE_MODULES_CACHE)),
+		plan.NewExecCommand(fmt.Sprintf("mkdir -p %s", strings.Join(append([]string{NODE_MODULES_CACHE}, p.workspaceNodeModules(ctx)...), " "))),
 	})
 
-	p.packageManager.installDependenciesDev(ctx, p.workspace, install)
+	p.packageManager.installDependenciesDev(ctx, p.workspace, p.workspacePackage, install)
 }
 
 func (p *NodeProvider) InstallMisePackages(ctx *generate.GenerateContext, miseStep *generate.MiseStepBuilder) {
@@ -625,6 +791,10 @@ func (p *NodeProvider) GetNodeEnvVars(ctx *generate.GenerateContext) map[string]
 }
 
 func (p *NodeProvider) hasDependency(dependency string) bool {
+	if p.workspacePackage != nil && p.workspacePackage.PackageJson.hasDependency(dependency) {
+		return true
+	}
+
 	return p.packageJson.hasDependency(dependency)
 }
 
@@ -632,10 +802,6 @@ func (p *NodeProvider) usesCorepack() bool {
 	return p.packageJson != nil && p.packageJson.PackageManager != nil && p.packageManager != PackageManagerBun
 }
 
-func (p *NodeProvider) usesPuppeteer() bool {
-	return p.workspace.HasDependency("puppeteer")
-}
-
 func (p *NodeProvider) getPackageManager(app *app.App) PackageManager {
 	packageManager := PackageManagerNpm
 
@@ -703,6 +869,26 @@ func (p *NodeProvider) SetNodeMetadata(ctx *generate.GenerateContext) {
 	ctx.Metadata.Set("nodePackageManager", string(p.packageManager))
 	ctx.Metadata.SetBool("nodeIsSPA", p.isSPA(ctx))
 	ctx.Metadata.SetBool("nodeUsesCorepack", p.usesCorepack())
+
+	if p.workspacePackage != nil {
+		ctx.Metadata.Set("nodeWorkspace", p.workspacePackage.Path)
+	}
+
+	if adapter := p.getSvelteKitAdapter(ctx); adapter != "" {
+		ctx.Metadata.Set("nodeSvelteKitAdapter", adapter)
+	}
+
+	if p.isNitro() {
+		if preset := p.getNitroPreset(ctx); preset != "" {
+			ctx.Metadata.Set("nodeNitroPreset", preset)
+		}
+	}
+
+	if p.isTurbo(ctx) {
+		ctx.Metadata.Set("nodeMonorepoTool", "turbo")
+	} else if p.isNx(ctx) {
+		ctx.Metadata.Set("nodeMonorepoTool", "nx")
+	}
 }
 
 func (p *NodeProvider) getPackagesWithFramework(ctx *generate.GenerateContext, frameworkCheck func(*WorkspacePackage, *generate.GenerateContext) bool) ([]*WorkspacePackage, error) {
@@ -760,7 +946,7 @@ func (p *NodeProvider) requiresBun(ctx *generate.GenerateContext) bool {
 		return true
 	}
 
-	if packageJsonRequiresBun(p.packageJson) {
+	if packageJsonRequiresBun(p.packageJson) || p.usesBunRuntime() {
 		return true
 	}
 
@@ -768,12 +954,18 @@ func (p *NodeProvider) requiresBun(ctx *generate.GenerateContext) bool {
 		return true
 	}
 
+	if p.packageJson != nil && p.isNitro() && p.getNitroPreset(ctx) == "bun" {
+		return true
+	}
+
 	return false
 }
 
 func (p *NodeProvider) getRuntime(ctx *generate.GenerateContext) string {
 	if p.isSPA(ctx) {
-		if p.isAstro(ctx) {
+		if framework := p.getStaticAdapterFramework(ctx); framework != "" {
+			return framework
+		} else if p.isAstro(ctx) {
 			return "astro"
 		} else if p.isVite(ctx) {
 			return "vite"
@@ -790,6 +982,10 @@ func (p *NodeProvider) getRuntime(ctx *generate.GenerateContext) string {
 		return "next"
 	} else if p.isNuxt() {
 		return "nuxt"
+	} else if p.isSolidStart() {
+		return "solid-start"
+	} else if p.isSvelteKit() {
+		return "sveltekit"
 	} else if p.isRemix() {
 		return "remix"
 	} else if p.isTanstackStart() {
@@ -820,3 +1016,138 @@ func (p *NodeProvider) isRemix() bool {
 func (p *NodeProvider) isTanstackStart() bool {
 	return p.hasDependency("@tanstack/react-start")
 }
+
+// packageDir returns the directory of the package being built relative to the app root
+func (p *NodeProvider) packageDir() string {
+	if p.workspacePackage != nil {
+		return p.workspacePackage.Path
+	}
+	return ""
+}
+
+func (p *NodeProvider) getWorkspaceName(ctx *generate.GenerateContext) string {
+	if ctx.Config == nil || ctx.Config.Node == nil {
+		return ""
+	}
+	return ctx.Config.Node.Workspace
+}
+
+func (p *NodeProvider) workspacePackagePaths() []string {
+	paths := []string{}
+	if p.workspace != nil {
+		for _, pkg := range p.workspace.Packages {
+			paths = append(paths, pkg.Path)
+		}
+	}
+	return paths
+}
+
+// workspaceBuildPackages returns the local dependencies of the workspace package followed by the package itself
+func (p *NodeProvider) workspaceBuildPackages() []*WorkspacePackage {
+	return append(p.workspace.LocalDependencies(p.workspacePackage), p.workspacePackage)
+}
+
+// buildWorkspacePackage builds the workspace package after building its local dependencies
+func (p *NodeProvider) buildWorkspacePackage(ctx *generate.GenerateContext, build *generate.CommandStepBuilder) {
+	p.addMonorepoVariables(ctx, build)
+
+	// Turbo and nx build the packages the workspace package depends on from their task graph
+	if p.isTurbo(ctx) {
+		build.AddCommand(plan.NewExecCommand(p.turboBuildCommand()))
+	} else if p.isNx(ctx) {
+		build.AddCommand(plan.NewExecCommand(p.nxBuildCommand(ctx)))
+	} else {
+		p.buildWorkspacePackageScripts(build)
+	}
+
+	if p.isNext() {
+		build.AddVariables(map[string]string{"NEXT_TELEMETRY_DISABLED": "1"})
+	}
+}
+
+func (p *NodeProvider) buildWorkspacePackageScripts(build *generate.CommandStepBuilder) {
+	for _, pkg := range p.workspaceBuildPackages() {
+		if pkg.PackageJson.HasScript("build") {
+			build.AddCommands([]plan.Command{
+				plan.NewExecCommand(p.packageManager.WorkspaceRunCmd(pkg, "build")),
+			})
+		}
+	}
+}
+
+func (p *NodeProvider) addMonorepoVariables(ctx *generate.GenerateContext, build *generate.CommandStepBuilder) {
+	if p.isTurbo(ctx) {
+		build.AddVariables(p.turboVariables())
+	}
+	if p.isNx(ctx) {
+		build.AddVariables(p.nxVariables())
+	}
+}
+
+func (p *NodeProvider) getWorkspaceStartCommand(ctx *generate.GenerateContext) string {
+	pkg := p.workspacePackage
+
+	if pkg.PackageJson.HasScript("start") {
+		return p.packageManager.WorkspaceRunCmd(pkg, "start")
+	} else if frameworkStart := p.getFrameworkStartCommand(ctx); frameworkStart != "" {
+		return frameworkStart
+	} else if main := pkg.PackageJson.Main; main != "" {
+		return p.packageManager.RunScriptCommand(path.Join(pkg.Path, main))
+	} else if bunStart := p.getBunStartCommand(ctx); bunStart != "" {
+		return bunStart
+	} else if files, err := ctx.App.FindFiles(path.Join(pkg.Path, "{index.js,index.ts}")); err == nil && len(files) > 0 {
+		return p.packageManager.RunScriptCommand(files[0])
+	}
+
+	return ""
+}
+
+// workspaceDeployPaths returns the app files needed to run the workspace package:
+// the root files used by the package manager, the package, and its local dependencies
+func (p *NodeProvider) workspaceDeployPaths(ctx *generate.GenerateContext) []string {
+	paths := []string{}
+	for _, file := range []string{"package.json", "package-lock.json", "pnpm-workspace.yaml", "pnpm-lock.yaml", "yarn.lock", ".yarnrc.yml", "bun.lock", "bun.lockb", ".npmrc"} {
+		if ctx.App.HasFile(file) {
+			paths = append(paths, file)
+		}
+	}
+
+	for _, pkg := range p.workspaceBuildPackages() {
+		paths = append(paths, pkg.Path)
+	}
+
+	return paths
+}
+
+// workspaceNodeModules returns the node_modules directories of the workspace package and its local dependencies.
+// Package managers put dependencies here that are not hoisted to the root node_modules.
+func (p *NodeProvider) workspaceNodeModules(ctx *generate.GenerateContext) []string {
+	if p.workspacePackage == nil || !slices.Contains(p.packageManager.GetInstallFolder(ctx), "/app/node_modules") {
+		return nil
+	}
+
+	dirs := []string{}
+	for _, pkg := range p.workspaceBuildPackages() {
+		dirs = append(dirs, path.Join("/app", pkg.Path, "node_modules"))
+	}
+	return dirs
+}
+
+// Mapping of npm dependencies to the apt packages needed to build and run them. Dependencies with
+// prebuilt binaries (e.g. sharp and the Prisma engines) only need packages that are already in the images.
+
+var chromiumRuntimePackages = []string{"xvfb", "gconf-service", "libasound2", "libatk1.0-0", "libc6", "libcairo2", "libcups2", "libdbus-1-3", "libexpat1", "libfontconfig1", "libgbm1", "libgcc1", "libgconf-2-4", "libgdk-pixbuf2.0-0", "libglib2.0-0", "libgtk-3-0", "libnspr4", "libpango-1.0-0", "libpangocairo-1.0-0", "libstdc++6", "libx11-6", "libx11-xcb1", "libxcb1", "libxcomposite1", "libxcursor1", "libxdamage1", "libxext6", "libxfixes3", "libxi6", "libxrandr2", "libxrender1", "libxss1", "libxtst6", "ca-certificates", "fonts-liberation", "libappindicator1", "libnss3", "lsb-release", "xdg-utils", "wget"}
+
+var nodeSystemPackages = map[string]config.SystemPackages{
+	"puppeteer":        {Runtime: chromiumRuntimePackages},
+	"playwright":       {Runtime: chromiumRuntimePackages},
+	"@playwright/test": {Runtime: chromiumRuntimePackages},
+	"canvas": {
+		Build:   []string{"libcairo2-dev", "libpango1.0-dev", "libjpeg-dev", "libgif-dev", "librsvg2-dev"},
+		Runtime: []string{"libcairo2", "libpango-1.0-0", "libpangocairo-1.0-0", "libjpeg62-turbo", "libgif7", "librsvg2-2"},
+	},
+	// node-gyp needs python to compile the addons when there is no prebuilt binary
+	"bcrypt":         {Build: []string{"python3"}},
+	"sqlite3":        {Build: []string{"python3"}},
+	"better-sqlite3": {Build: []string{"python3"}},
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/railwayapp/railpack/core/config"
 	"github.com/railwayapp/railpack/core/generate"
 	"github.com/railwayapp/railpack/core/logger"
+	testingUtils "github.com/railwayapp/railpack/core/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -84,7 +85,7 @@ func TestNode_Dev_HasRequiredPort(t *testing.T) {
 			require.NoError(t, err)
 			ctx.Dev = true
 
-			port := provider.getDevPort(ctx)
+			port, _ := provider.getDevPort(ctx)
 			assert.Equal(t, tt.expected, port)
 		})
 	}
@@ -104,7 +105,7 @@ func TestNode_Dev_NoRequiredPortInProduction(t *testing.T) {
 	require.NoError(t, err)
 	ctx.Dev = false // Production mode
 
-	port := provider.getDevPort(ctx)
+	port, _ := provider.getDevPort(ctx)
 	assert.Equal(t, "3000", port) // Should still return default port for production
 }
 
@@ -156,7 +157,7 @@ func TestNode_Dev_FrameworkDetection(t *testing.T) {
 			require.NoError(t, err)
 			ctx.Dev = true
 
-			port := provider.getDevPort(ctx)
+			port, _ := provider.getDevPort(ctx)
 			assert.Equal(t, tt.expected, port)
 		})
 	}
@@ -251,3 +252,136 @@ func TestNode_Dev_NoFrameworkEnvVarsInProduction(t *testing.T) {
 	assert.Empty(t, envVars["HOSTNAME"])
 	assert.Empty(t, envVars["NUXT_HOST"])
 }
+
+func TestNode_Dev_PortFromSources(t *testing.T) {
+	tests := []struct {
+		name           string
+		files          map[string]string
+		expectedPort   string
+		expectedSource string
+	}{
+		{
+			name: "dev script flag",
+			files: map[string]string{
+				"package.json": `{"scripts": {"dev": "next dev -p 4000", "build": "next build"}, "dependencies": {"next": "15"}}`,
+			},
+			expectedPort:   "4000",
+			expectedSource: "package.json > scripts > dev",
+		},
+		{
+			name: "vite config",
+			files: map[string]string{
+				"package.json":   `{"scripts": {"dev": "vite", "build": "vite build"}, "devDependencies": {"vite": "6"}}`,
+				"vite.config.ts": "export default defineConfig({\n  server: {\n    host: true,\n    port: 3001,\n  },\n})\n",
+			},
+			expectedPort:   "3001",
+			expectedSource: "vite.config.ts",
+		},
+		{
+			name: "angular.json",
+			files: map[string]string{
+				"package.json": `{"scripts": {"start": "ng serve", "build": "ng build"}, "dependencies": {"@angular/core": "19"}}`,
+				"angular.json": `{"projects": {"app": {"architect": {"build": {"options": {"outputPath": "dist/app"}}, "serve": {"options": {"port": 4300}}}}}}`,
+			},
+			expectedPort:   "4300",
+			expectedSource: "angular.json",
+		},
+		{
+			name: "framework default",
+			files: map[string]string{
+				"package.json": `{"scripts": {"dev": "vite", "build": "vite build"}, "devDependencies": {"vite": "6"}}`,
+			},
+			expectedPort:   "5173",
+			expectedSource: generate.PortSourceDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContextFromFiles(t, tt.files)
+			ctx.Dev = true
+
+			provider := &NodeProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+			require.NoError(t, provider.Plan(ctx))
+
+			require.Equal(t, tt.expectedPort, ctx.Deploy.RequiredPort)
+			require.Equal(t, tt.expectedSource, ctx.Metadata.Get("requiredPortSource"))
+		})
+	}
+}
+
+func TestNode_Debug(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		startCmd string
+		hostCmd  string
+	}{
+		{
+			name: "dev script",
+			files: map[string]string{
+				"package.json": `{"scripts": {"dev": "vite", "build": "vite build"}, "devDependencies": {"vite": "6"}}`,
+			},
+			startCmd: "npm_config_node_options=--inspect=0.0.0.0:9229 npm run dev",
+			hostCmd:  "npm_config_node_options=--inspect=0.0.0.0:9229 npm run dev -- --host",
+		},
+		{
+			name: "start script",
+			files: map[string]string{
+				"package.json": `{"scripts": {"start": "node server.js"}}`,
+			},
+			startCmd: "node --inspect=0.0.0.0:9229 server.js",
+			hostCmd:  "node --inspect=0.0.0.0:9229 server.js",
+		},
+		{
+			name: "nodemon",
+			files: map[string]string{
+				"package.json": `{"scripts": {"dev": "nodemon server.js"}, "devDependencies": {"nodemon": "3"}}`,
+			},
+			startCmd: "npx nodemon --inspect=0.0.0.0:9229 server.js",
+			hostCmd:  "npx nodemon --inspect=0.0.0.0:9229 server.js",
+		},
+		{
+			name: "tsx watch",
+			files: map[string]string{
+				"package.json": `{"scripts": {"dev": "tsx watch src/index.ts"}, "devDependencies": {"tsx": "4"}}`,
+			},
+			startCmd: "npx tsx watch --inspect=0.0.0.0:9229 src/index.ts",
+			hostCmd:  "npx tsx watch --inspect=0.0.0.0:9229 src/index.ts",
+		},
+		{
+			name: "pnpm dev script",
+			files: map[string]string{
+				"package.json":   `{"scripts": {"dev": "next dev"}, "dependencies": {"next": "15"}}`,
+				"pnpm-lock.yaml": "lockfileVersion: '9.0'",
+			},
+			startCmd: "npm_config_node_options=--inspect=0.0.0.0:9229 pnpm dev",
+			hostCmd:  "npm_config_node_options=--inspect=0.0.0.0:9229 pnpm dev -H 0.0.0.0",
+		},
+		{
+			name: "main file",
+			files: map[string]string{
+				"package.json": `{"main": "server.js"}`,
+			},
+			startCmd: "node --inspect=0.0.0.0:9229 server.js",
+			hostCmd:  "node --inspect=0.0.0.0:9229 server.js",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContextFromFiles(t, tt.files)
+			ctx.Dev = true
+			ctx.Debug = true
+
+			provider := &NodeProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+			require.NoError(t, provider.Plan(ctx))
+
+			require.Equal(t, tt.startCmd, ctx.Deploy.StartCmd)
+			require.Equal(t, tt.hostCmd, ctx.Deploy.StartCmdHost)
+			require.Contains(t, ctx.Deploy.RequiredPort, NODE_INSPECT_PORT)
+		})
+	}
+}
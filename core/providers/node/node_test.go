@@ -5,7 +5,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/railwayapp/railpack/core/config"
 	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/logger"
+	"github.com/railwayapp/railpack/core/plan"
 	testingUtils "github.com/railwayapp/railpack/core/testing"
 	"github.com/stretchr/testify/require"
 )
@@ -230,3 +233,380 @@ func TestPackageJsonRequiresBun(t *testing.T) {
 		require.False(t, got)
 	})
 }
+
+func TestNodeWorkspacePackage(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-pnpm-workspace-package")
+	ctx.Config.Node = &config.NodeConfig{Workspace: "apps/api"}
+
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Equal(t, "apps/api", ctx.Metadata.Get("nodeWorkspace"))
+	require.Equal(t, "pnpm --filter @acme/api run start", ctx.Deploy.StartCmd)
+
+	buildPlan, _, err := ctx.Generate()
+	require.NoError(t, err)
+
+	commands := map[string][]string{}
+	for _, step := range buildPlan.Steps {
+		for _, cmd := range step.Commands {
+			if execCmd, ok := cmd.(plan.ExecCommand); ok {
+				commands[step.Name] = append(commands[step.Name], execCmd.Cmd)
+			}
+		}
+	}
+
+	require.Contains(t, commands["install"], "pnpm install --frozen-lockfile --prefer-offline --filter @acme/api...")
+	require.Equal(t, []string{"pnpm --filter @acme/api run build"}, commands["build"])
+
+	buildLayer := buildPlan.Deploy.Inputs[len(buildPlan.Deploy.Inputs)-1]
+	require.Equal(t, []string{"/root/.cache", "package.json", "pnpm-workspace.yaml", "pnpm-lock.yaml", "packages/shared", "apps/api"}, buildLayer.Include)
+}
+
+func TestNodeWorkspacePackageNotFound(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-pnpm-workspace-package")
+	ctx.Config.Node = &config.NodeConfig{Workspace: "apps/missing"}
+
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+
+	err := provider.Plan(ctx)
+	require.EqualError(t, err, "workspace package `apps/missing` not found. Available packages: apps/api, apps/web, packages/shared")
+}
+
+func TestWorkspaceRunCmd(t *testing.T) {
+	pkg := &WorkspacePackage{Path: "apps/api", PackageJson: &PackageJson{Name: "@acme/api"}}
+
+	require.Equal(t, "npm run build -w apps/api", PackageManagerNpm.WorkspaceRunCmd(pkg, "build"))
+	require.Equal(t, "pnpm --filter @acme/api run build", PackageManagerPnpm.WorkspaceRunCmd(pkg, "build"))
+	require.Equal(t, "bun run --filter @acme/api build", PackageManagerBun.WorkspaceRunCmd(pkg, "build"))
+	require.Equal(t, "yarn workspace @acme/api run build", PackageManagerYarnBerry.WorkspaceRunCmd(pkg, "build"))
+}
+
+func TestNodeTurboWorkspacePackage(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-turborepo")
+	ctx.Config.Node = &config.NodeConfig{Workspace: "apps/web"}
+
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Equal(t, "turbo", ctx.Metadata.Get("nodeMonorepoTool"))
+	require.Equal(t, TURBO_CACHE_DIR, ctx.Caches.GetCache("turbo").Directory)
+
+	buildPlan, _, err := ctx.Generate()
+	require.NoError(t, err)
+
+	steps := map[string]plan.Step{}
+	for _, step := range buildPlan.Steps {
+		steps[step.Name] = step
+	}
+
+	require.Contains(t, steps, "turbo-prune")
+	require.Equal(t, plan.ExecCommand{Cmd: "npx --yes turbo@^2.4.1 prune web --docker"}, steps["turbo-prune"].Commands[0])
+
+	// The install step gets its manifests from the pruned workspace instead of the local source
+	require.Equal(t, "turbo-prune", steps["install"].Inputs[1].Step)
+	for _, cmd := range steps["install"].Commands {
+		if copyCmd, ok := cmd.(plan.CopyCommand); ok {
+			require.NotEqual(t, "apps/docs/package.json", copyCmd.Src)
+		}
+	}
+
+	require.Equal(t, []plan.Command{plan.ExecCommand{Cmd: "turbo run build --filter=web"}}, steps["build"].Commands)
+	require.Equal(t, "1", steps["build"].Variables["TURBO_TELEMETRY_DISABLED"])
+}
+
+func TestNodeNxWorkspacePackage(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-nx-workspace")
+	ctx.Config.Node = &config.NodeConfig{Workspace: "apps/api"}
+
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Equal(t, "nx", ctx.Metadata.Get("nodeMonorepoTool"))
+	require.Equal(t, NX_CACHE_DIR, ctx.Caches.GetCache("nx").Directory)
+
+	buildPlan, _, err := ctx.Generate()
+	require.NoError(t, err)
+
+	for _, step := range buildPlan.Steps {
+		require.NotEqual(t, "turbo-prune", step.Name)
+
+		if step.Name == "build" {
+			// The project name comes from project.json
+			require.Equal(t, []plan.Command{plan.ExecCommand{Cmd: "nx build api"}}, step.Commands)
+			require.Equal(t, "false", step.Variables["NX_DAEMON"])
+		}
+	}
+}
+
+func TestNodeNextStandalone(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-next-standalone")
+
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.True(t, provider.isNextStandalone(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Equal(t, "node server.js", ctx.Deploy.StartCmd)
+	require.Equal(t, "0.0.0.0", ctx.Deploy.Variables["HOSTNAME"])
+
+	buildPlan, _, err := ctx.Generate()
+	require.NoError(t, err)
+
+	// Only the standalone output is deployed, not node_modules or the source
+	require.Len(t, buildPlan.Deploy.Inputs, 2)
+	require.Equal(t, "next-standalone", buildPlan.Deploy.Inputs[1].Step)
+
+	for _, step := range buildPlan.Steps {
+		if step.Name == "next-standalone" {
+			require.Equal(t, []string{".next/standalone", ".next/static", "public"}, step.Inputs[1].Include)
+		}
+	}
+}
+
+func TestNodeNextWithoutStandalone(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-next")
+
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.False(t, provider.isNextStandalone(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Equal(t, "npm run start", ctx.Deploy.StartCmd)
+
+	warned := false
+	for _, log := range ctx.Logger.Logs {
+		if log.Level == logger.Warn && strings.Contains(log.Msg, "standalone output is not enabled") {
+			warned = true
+		}
+	}
+	require.True(t, warned)
+}
+
+func TestNodeFrameworkAdapters(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		files        map[string]string
+		isSPA        bool
+		spaFramework string
+		outputDir    string
+		startCmd     string
+	}{
+		{
+			name:         "sveltekit static",
+			path:         "../../../examples/node-svelte-kit-static",
+			isSPA:        true,
+			spaFramework: "sveltekit",
+			outputDir:    "dist",
+			startCmd:     "caddy run --config /Caddyfile --adapter caddyfile 2>&1",
+		},
+		{
+			name: "sveltekit node",
+			files: map[string]string{
+				"package.json":     `{"scripts": {"build": "vite build"}, "devDependencies": {"@sveltejs/kit": "^2.0.0", "@sveltejs/adapter-node": "^5.0.0", "vite": "^6.0.0"}}`,
+				"vite.config.ts":   `export default {}`,
+				"svelte.config.js": `import adapter from "@sveltejs/adapter-node"; export default { kit: { adapter: adapter({ out: "server" }) } };`,
+			},
+			startCmd: "node server",
+		},
+		{
+			name:     "sveltekit auto",
+			path:     "../../../examples/node-svelte-kit",
+			startCmd: "",
+		},
+		{
+			name:     "nuxt",
+			path:     "../../../examples/node-nuxt",
+			startCmd: "node .output/server/index.mjs",
+		},
+		{
+			name: "nuxt static preset",
+			files: map[string]string{
+				"package.json":   `{"scripts": {"build": "nuxt build"}, "dependencies": {"nuxt": "^3.0.0"}}`,
+				"nuxt.config.ts": `export default defineNuxtConfig({ nitro: { preset: "static" } })`,
+			},
+			isSPA:        true,
+			spaFramework: "nuxt",
+			outputDir:    ".output/public",
+			startCmd:     "caddy run --config /Caddyfile --adapter caddyfile 2>&1",
+		},
+		{
+			name: "nuxt generate",
+			files: map[string]string{
+				"package.json": `{"scripts": {"build": "nuxt generate"}, "dependencies": {"nuxt": "^3.0.0"}}`,
+			},
+			isSPA:        true,
+			spaFramework: "nuxt",
+			outputDir:    ".output/public",
+			startCmd:     "caddy run --config /Caddyfile --adapter caddyfile 2>&1",
+		},
+		{
+			name:     "solid start",
+			path:     "../../../examples/node-solid-start",
+			startCmd: "node .output/server/index.mjs",
+		},
+		{
+			name: "remix without start script",
+			files: map[string]string{
+				"package.json":   `{"scripts": {"build": "remix vite:build"}, "dependencies": {"@remix-run/node": "^2.0.0", "@remix-run/serve": "^2.0.0"}, "devDependencies": {"@remix-run/dev": "^2.0.0", "vite": "^6.0.0"}}`,
+				"vite.config.ts": `export default defineConfig({ plugins: [remix()] })`,
+			},
+			startCmd: "npx remix-serve build/server/index.js",
+		},
+		{
+			name: "remix spa mode",
+			files: map[string]string{
+				"package.json":   `{"scripts": {"build": "remix vite:build"}, "dependencies": {"@remix-run/react": "^2.0.0"}, "devDependencies": {"@remix-run/dev": "^2.0.0", "vite": "^6.0.0"}}`,
+				"vite.config.ts": `export default defineConfig({ plugins: [remix({ ssr: false })] })`,
+			},
+			isSPA:        true,
+			spaFramework: "remix",
+			outputDir:    "build/client",
+			startCmd:     "caddy run --config /Caddyfile --adapter caddyfile 2>&1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if path == "" {
+				path = testingUtils.CreateAppDir(t, tt.files)
+			}
+
+			ctx := testingUtils.CreateGenerateContext(t, path)
+			provider := NodeProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+
+			require.Equal(t, tt.isSPA, provider.isSPA(ctx))
+			require.Equal(t, tt.spaFramework, provider.getSPAFramework(ctx))
+			if tt.isSPA {
+				require.Equal(t, tt.outputDir, provider.getOutputDirectory(ctx))
+			}
+
+			require.NoError(t, provider.Plan(ctx))
+			require.Equal(t, tt.startCmd, ctx.Deploy.StartCmd)
+		})
+	}
+}
+
+func TestNodeBunEntrypoint(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-bun-serve")
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+
+	require.True(t, provider.usesBunRuntime())
+	require.True(t, provider.requiresBun(ctx))
+	require.Equal(t, "src/index.ts", provider.getBunEntrypoint(ctx))
+
+	require.NoError(t, provider.Plan(ctx))
+	require.Equal(t, "bun run src/index.ts", ctx.Deploy.StartCmd)
+}
+
+func TestNodeBunDev(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-bun-serve")
+	ctx.Dev = true
+
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Equal(t, "bun --hot src/index.ts", ctx.Deploy.StartCmd)
+	require.Equal(t, "bun --hot src/index.ts", ctx.Deploy.StartCmdHost)
+}
+
+func TestNodeBunCompile(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-bun-serve")
+	ctx.Env.SetVariable("RAILPACK_BUN_COMPILE", "1")
+
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Equal(t, "./out", ctx.Deploy.StartCmd)
+
+	buildPlan, _, err := ctx.Generate()
+	require.NoError(t, err)
+
+	// Only the compiled binary is deployed
+	require.Len(t, buildPlan.Deploy.Inputs, 1)
+	require.Equal(t, []string{"out"}, buildPlan.Deploy.Inputs[0].Include)
+	require.Equal(t, plan.RailpackRuntimeImage, buildPlan.Deploy.Base.Image)
+	require.False(t, buildPlan.Deploy.NoShell)
+
+	for _, step := range buildPlan.Steps {
+		if step.Name == "build" {
+			lastCommand := step.Commands[len(step.Commands)-1].(plan.ExecCommand)
+			require.Equal(t, "bun build --compile --minify --sourcemap src/index.ts --outfile out", lastCommand.Cmd)
+		}
+	}
+}
+
+func TestNodeBunCompileStaticBase(t *testing.T) {
+	// The binary is linked against glibc, so scratch also uses the cc image
+	for _, base := range []string{"distroless", "scratch"} {
+		t.Run(base, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-bun-serve")
+			ctx.Env.SetVariable("RAILPACK_BUN_COMPILE", "1")
+			ctx.Env.SetVariable("RAILPACK_STATIC_BASE", base)
+
+			provider := NodeProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+			require.NoError(t, provider.Plan(ctx))
+
+			buildPlan, _, err := ctx.Generate()
+			require.NoError(t, err)
+
+			require.Equal(t, plan.DistrolessCCImage, buildPlan.Deploy.Base.Image)
+			require.True(t, buildPlan.Deploy.NoShell)
+			require.Equal(t, "./out", buildPlan.Deploy.StartCmd)
+			require.Len(t, buildPlan.Deploy.Inputs, 1)
+			require.Equal(t, []string{"out"}, buildPlan.Deploy.Inputs[0].Include)
+		})
+	}
+}
+
+func TestNodeBunWorkspace(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-bun-workspaces")
+	ctx.Config.Node = &config.NodeConfig{Workspace: "api"}
+
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+
+	// Excluded packages are not part of the workspace
+	require.Len(t, provider.workspace.Packages, 2)
+	require.Nil(t, provider.workspace.GetPackage("packages/legacy"))
+
+	require.NoError(t, provider.Plan(ctx))
+	require.Equal(t, "bun run packages/api/src/index.ts", ctx.Deploy.StartCmd)
+}
+
+func TestNodeSystemPackages(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContextFromFiles(t, map[string]string{
+		"package.json": `{
+		"scripts": { "start": "node index.js" },
+		"dependencies": { "bcrypt": "^5.1.1", "canvas": "^3.1.0" }
+	}`,
+	})
+	ctx.Config.SystemPackages = map[string]map[string]*config.SystemPackages{
+		"node": {"bcrypt": {Build: []string{"python3", "make"}}},
+	}
+
+	provider := NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Subset(t, ctx.GetMiseStepBuilder().SupportingAptPackages, []string{"python3", "make", "libcairo2-dev"})
+	require.Equal(t, nodeSystemPackages["canvas"].Runtime, ctx.Deploy.AptPackages)
+
+	ctx = testingUtils.CreateGenerateContext(t, "../../../examples/node-puppeteer")
+	provider = NodeProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Equal(t, chromiumRuntimePackages, ctx.Deploy.AptPackages)
+}
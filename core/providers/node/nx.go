@@ -0,0 +1,48 @@
+package node
+
+import (
+	"path"
+
+	"github.com/railwayapp/railpack/core/generate"
+)
+
+const (
+	NX_CACHE_DIR = "/app/.nx/cache"
+)
+
+type NxProjectJson struct {
+	Name string `json:"name"`
+}
+
+func (p *NodeProvider) isNx(ctx *generate.GenerateContext) bool {
+	return ctx.App.HasFile("nx.json")
+}
+
+// nxBuildCommand returns the nx command to build the workspace package, or every project if no package is targeted.
+// Nx builds the projects the package depends on first.
+func (p *NodeProvider) nxBuildCommand(ctx *generate.GenerateContext) string {
+	if p.workspacePackage != nil {
+		return "nx build " + p.nxProjectName(ctx, p.workspacePackage)
+	}
+	return "nx run-many -t build"
+}
+
+// nxProjectName returns the name of the nx project for a workspace package.
+// This is the name in project.json if there is one, otherwise the package name.
+func (p *NodeProvider) nxProjectName(ctx *generate.GenerateContext, pkg *WorkspacePackage) string {
+	var projectJson NxProjectJson
+	if err := ctx.App.ReadJSON(path.Join(pkg.Path, "project.json"), &projectJson); err == nil && projectJson.Name != "" {
+		return projectJson.Name
+	}
+
+	if pkg.PackageJson.Name != "" {
+		return pkg.PackageJson.Name
+	}
+
+	return path.Base(pkg.Path)
+}
+
+func (p *NodeProvider) nxVariables() map[string]string {
+	// The nx daemon is not useful for a single build and can hang in containers
+	return map[string]string{"NX_DAEMON": "false"}
+}
@@ -3,6 +3,7 @@ package node
 import (
 	"encoding/json"
 	"maps"
+	"slices"
 	"strings"
 )
 
@@ -58,6 +59,15 @@ func (p *PackageJson) hasDependency(dependency string) bool {
 	return false
 }
 
+// dependencyNames returns the sorted names of all dependencies and dev dependencies
+func (p *PackageJson) dependencyNames() []string {
+	allDeps := make(map[string]string)
+	maps.Copy(allDeps, p.Dependencies)
+	maps.Copy(allDeps, p.DevDependencies)
+
+	return slices.Sorted(maps.Keys(allDeps))
+}
+
 func (p *PackageJson) hasLocalDependency() bool {
 	allDeps := make(map[string]string)
 	maps.Copy(allDeps, p.Dependencies)
@@ -2,6 +2,8 @@ package node
 
 import (
 	"fmt"
+	"path"
+	"slices"
 	"strings"
 
 	semver "github.com/Masterminds/semver/v3"
@@ -45,24 +47,19 @@ func (p PackageManager) RunScriptCommand(cmd string) string {
 	return "node " + cmd
 }
 
-func (p PackageManager) installDependencies(ctx *generate.GenerateContext, workspace *Workspace, install *generate.CommandStepBuilder) {
-	packageJsons := workspace.AllPackageJson()
-
-	hasPreInstall := false
-	hasPostInstall := false
-	hasPrepare := false
-	usesLocalFile := false
-
-	for _, packageJson := range packageJsons {
-		hasPreInstall = hasPreInstall || (packageJson.Scripts != nil && packageJson.Scripts["preinstall"] != "")
-		hasPostInstall = hasPostInstall || (packageJson.Scripts != nil && packageJson.Scripts["postinstall"] != "")
-		hasPrepare = hasPrepare || (packageJson.Scripts != nil && packageJson.Scripts["prepare"] != "")
-		usesLocalFile = usesLocalFile || p.usesLocalFile(ctx)
+// ExecCmd returns the command to run a binary installed in node_modules
+func (p PackageManager) ExecCmd(cmd string) string {
+	if p == PackageManagerBun {
+		return "bunx " + cmd
 	}
+	return "npx " + cmd
+}
 
+// installDependencies installs the dependencies of the whole workspace, or only those of target if it is not nil
+func (p PackageManager) installDependencies(ctx *generate.GenerateContext, workspace *Workspace, target *WorkspacePackage, install *generate.CommandStepBuilder) {
 	// If there are any pre/post install scripts, we need the entire app to be copied
 	// This is to handle things like patch-package
-	if hasPreInstall || hasPostInstall || hasPrepare || usesLocalFile {
+	if p.requiresFullSource(ctx, workspace) {
 		install.AddCommands([]plan.Command{
 			plan.NewCopyCommand(".", "."),
 		})
@@ -77,28 +74,14 @@ func (p PackageManager) installDependencies(ctx *generate.GenerateContext, works
 		}
 	}
 
-	p.installDeps(ctx, install)
+	p.installDeps(ctx, target, install)
 }
 
 // installDependenciesDev installs dependencies in a dev-friendly way (avoids strict/frozen installs like `npm ci`)
-func (p PackageManager) installDependenciesDev(ctx *generate.GenerateContext, workspace *Workspace, install *generate.CommandStepBuilder) {
-	packageJsons := workspace.AllPackageJson()
-
-	hasPreInstall := false
-	hasPostInstall := false
-	hasPrepare := false
-	usesLocalFile := false
-
-	for _, packageJson := range packageJsons {
-		hasPreInstall = hasPreInstall || (packageJson.Scripts != nil && packageJson.Scripts["preinstall"] != "")
-		hasPostInstall = hasPostInstall || (packageJson.Scripts != nil && packageJson.Scripts["postinstall"] != "")
-		hasPrepare = hasPrepare || (packageJson.Scripts != nil && packageJson.Scripts["prepare"] != "")
-		usesLocalFile = usesLocalFile || p.usesLocalFile(ctx)
-	}
-
+func (p PackageManager) installDependenciesDev(ctx *generate.GenerateContext, workspace *Workspace, target *WorkspacePackage, install *generate.CommandStepBuilder) {
 	// If there are any pre/post install scripts, we need the entire app to be copied
 	// This is to handle things like patch-package
-	if hasPreInstall || hasPostInstall || hasPrepare || usesLocalFile {
+	if p.requiresFullSource(ctx, workspace) {
 		install.AddCommands([]plan.Command{
 			plan.NewCopyCommand(".", "."),
 		})
@@ -114,7 +97,19 @@ func (p PackageManager) installDependenciesDev(ctx *generate.GenerateContext, wo
 	}
 
 	// Use dev-friendly install commands
-	p.installDepsDev(ctx, install)
+	p.installDepsDev(ctx, target, install)
+}
+
+// requiresFullSource checks if installing dependencies needs the entire app,
+// because of install lifecycle scripts or local file dependencies
+func (p PackageManager) requiresFullSource(ctx *generate.GenerateContext, workspace *Workspace) bool {
+	for _, packageJson := range workspace.AllPackageJson() {
+		if packageJson.Scripts != nil && (packageJson.Scripts["preinstall"] != "" || packageJson.Scripts["postinstall"] != "" || packageJson.Scripts["prepare"] != "") {
+			return true
+		}
+	}
+
+	return p.usesLocalFile(ctx)
 }
 
 // GetCache returns the cache for the package manager
@@ -135,9 +130,14 @@ func (p PackageManager) GetInstallCache(ctx *generate.GenerateContext) string {
 	}
 }
 
-func (p PackageManager) installDeps(ctx *generate.GenerateContext, install *generate.CommandStepBuilder) {
+func (p PackageManager) installDeps(ctx *generate.GenerateContext, target *WorkspacePackage, install *generate.CommandStepBuilder) {
 	install.AddCache(p.GetInstallCache(ctx))
 
+	if target != nil {
+		p.installWorkspaceDeps(ctx, target, install, false)
+		return
+	}
+
 	switch p {
 	case PackageManagerNpm:
 		hasLockfile := ctx.App.HasMatch("package-lock.json")
@@ -163,9 +163,14 @@ func (p PackageManager) installDeps(ctx *generate.GenerateContext, install *gene
 }
 
 // installDepsDev uses non-frozen, non-CI install commands suitable for development
-func (p PackageManager) installDepsDev(ctx *generate.GenerateContext, install *generate.CommandStepBuilder) {
+func (p PackageManager) installDepsDev(ctx *generate.GenerateContext, target *WorkspacePackage, install *generate.CommandStepBuilder) {
 	install.AddCache(p.GetInstallCache(ctx))
 
+	if target != nil {
+		p.installWorkspaceDeps(ctx, target, install, true)
+		return
+	}
+
 	switch p {
 	case PackageManagerNpm:
 		install.AddCommand(plan.NewExecCommand("npm install"))
@@ -180,6 +185,56 @@ func (p PackageManager) installDepsDev(ctx *generate.GenerateContext, install *g
 	}
 }
 
+// installWorkspaceDeps only installs the dependencies of a single workspace package and its local dependencies
+func (p PackageManager) installWorkspaceDeps(ctx *generate.GenerateContext, target *WorkspacePackage, install *generate.CommandStepBuilder, dev bool) {
+	switch p {
+	case PackageManagerNpm:
+		if !dev && ctx.App.HasMatch("package-lock.json") {
+			install.AddCommand(plan.NewExecCommand("npm ci -w " + target.Path))
+		} else {
+			install.AddCommand(plan.NewExecCommand("npm install -w " + target.Path))
+		}
+	case PackageManagerPnpm:
+		// The trailing ... also selects the local packages that the target depends on
+		filter := fmt.Sprintf("--filter %s...", target.Selector())
+		if !dev && ctx.App.HasMatch("pnpm-lock.yaml") {
+			install.AddCommand(plan.NewExecCommand("pnpm install --frozen-lockfile --prefer-offline " + filter))
+		} else {
+			install.AddCommand(plan.NewExecCommand("pnpm install " + filter))
+		}
+	case PackageManagerBun:
+		if dev {
+			install.AddCommand(plan.NewExecCommand("bun install --filter " + target.Selector()))
+		} else {
+			install.AddCommand(plan.NewExecCommand("bun install --frozen-lockfile --filter " + target.Selector()))
+		}
+	case PackageManagerYarn1:
+		// Yarn 1 cannot install a single workspace, so every workspace is installed
+		ctx.Logger.LogWarn("Yarn 1 does not support installing a single workspace, installing all workspaces")
+		if dev {
+			install.AddCommand(plan.NewExecCommand("yarn install"))
+		} else {
+			install.AddCommand(plan.NewExecCommand("yarn install --frozen-lockfile"))
+		}
+	case PackageManagerYarnBerry:
+		install.AddCommand(plan.NewExecCommand("yarn workspaces focus " + target.Selector()))
+	}
+}
+
+// WorkspaceRunCmd returns the command to run a script of a single workspace package from the workspace root
+func (p PackageManager) WorkspaceRunCmd(pkg *WorkspacePackage, script string) string {
+	switch p {
+	case PackageManagerPnpm:
+		return fmt.Sprintf("pnpm --filter %s run %s", pkg.Selector(), script)
+	case PackageManagerBun:
+		return fmt.Sprintf("bun run --filter %s %s", pkg.Selector(), script)
+	case PackageManagerYarn1, PackageManagerYarnBerry:
+		return fmt.Sprintf("yarn workspace %s run %s", pkg.Selector(), script)
+	default:
+		return fmt.Sprintf("npm run %s -w %s", script, pkg.Path)
+	}
+}
+
 func (p PackageManager) PruneDeps(ctx *generate.GenerateContext, prune *generate.CommandStepBuilder) {
 	prune.AddCache(p.GetInstallCache(ctx))
 
@@ -315,6 +370,21 @@ func (p PackageManager) SupportingInstallFiles(ctx *generate.GenerateContext) []
 	return allFiles
 }
 
+// SupportingConfigFiles returns the supporting install files that are not package manifests or lock files.
+// These are needed alongside a pruned set of manifests (e.g. from turbo prune).
+func (p PackageManager) SupportingConfigFiles(ctx *generate.GenerateContext) []string {
+	manifests := []string{"package.json", "package-lock.json", "pnpm-workspace.yaml", "pnpm-lock.yaml", "yarn.lock", "bun.lock", "bun.lockb"}
+
+	files := []string{}
+	for _, file := range p.SupportingInstallFiles(ctx) {
+		if !slices.Contains(manifests, path.Base(file)) {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
 // GetPackageManagerPackages installs specific versions of package managers by analyzing the users code
 func (p PackageManager) GetPackageManagerPackages(ctx *generate.GenerateContext, packageJson *PackageJson, packages *generate.MiseStepBuilder) {
 	pmName, pmVersion := packageJson.GetPackageManagerInfo()
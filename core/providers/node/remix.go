@@ -0,0 +1,54 @@
+package node
+
+import (
+	"path"
+	"regexp"
+
+	"github.com/railwayapp/railpack/core/generate"
+)
+
+const (
+	DefaultRemixOutputDirectory    = "build"
+	DefaultRemixSPAOutputDirectory = "build/client"
+)
+
+var (
+	// remixSPAModeRegex matches `ssr: false` in the options of the Remix Vite plugin
+	remixSPAModeRegex = regexp.MustCompile(`\bssr\s*:\s*false\b`)
+)
+
+// isRemixSPA checks if Remix is built in SPA mode, which only outputs static files
+func (p *NodeProvider) isRemixSPA(ctx *generate.GenerateContext) bool {
+	if !p.hasDependency("@remix-run/dev") {
+		return false
+	}
+
+	files, err := ctx.App.FindFiles(path.Join(p.packageDir(), "vite.config.{js,ts,mjs,mts}"))
+	if err != nil {
+		return false
+	}
+
+	for _, file := range files {
+		contents, err := ctx.App.ReadFile(file)
+		if err == nil && remixSPAModeRegex.MatchString(contents) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getRemixStartCommand returns the command to serve the Remix server build with remix-serve.
+// Vite builds output the server to build/server, while the classic compiler outputs to build.
+func (p *NodeProvider) getRemixStartCommand(ctx *generate.GenerateContext) string {
+	if !p.hasDependency("@remix-run/serve") {
+		return ""
+	}
+
+	server := path.Join(p.packageDir(), DefaultRemixOutputDirectory, "index.js")
+	if files, err := ctx.App.FindFiles(path.Join(p.packageDir(), "vite.config.{js,ts,mjs,mts}")); err == nil && len(files) > 0 {
+		server = path.Join(p.packageDir(), DefaultRemixOutputDirectory, "server/index.js")
+	}
+
+	return p.packageManager.ExecCmd("remix-serve " + server)
+}
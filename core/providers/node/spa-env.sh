@@ -0,0 +1,47 @@
+#!/bin/sh
+# Replaces the placeholders that were built into the static files with the values of the
+# variables at runtime, and writes them to env.js as window.__ENV__
+# Usage: spa-env.sh <dir> <name>...
+set -e
+
+dir="$1"
+shift
+
+# join_lines joins the lines of stdin with $1, so no value can span multiple lines of a file
+join_lines() {
+	sep="$1" awk 'NR > 1 { printf "%s", ENVIRON["sep"] } { printf "%s", $0 }'
+}
+
+# Placeholders in .js files are inside string literals, so values are escaped for any JS quote style
+escape_js() {
+	printf '%s' "$1" | sed -e 's/\\/\\\\/g' -e 's/"/\\"/g' -e "s/'/\\\\'/g" -e 's/`/\\`/g' \
+		-e 's/\$/\\$/g' -e 's/\r/\\r/g' -e 's/</\\x3c/g' -e 's/>/\\x3e/g' | join_lines '\n'
+}
+
+escape_html() {
+	printf '%s' "$1" | sed -e 's/&/\&amp;/g' -e 's/</\&lt;/g' -e 's/>/\&gt;/g' -e 's/"/\&quot;/g' \
+		-e "s/'/\\&#39;/g" | join_lines '&#10;'
+}
+
+escape_css() {
+	printf '%s' "$1" | sed -e 's/\\/\\\\/g' -e 's/"/\\"/g' -e "s/'/\\\\'/g" -e 's/</\\3c /g' | join_lines '\a '
+}
+
+# replace <name> <extension> <escaped value>
+replace() {
+	replacement=$(printf '%s' "$3" | sed -e 's/[\\|&]/\\&/g')
+	find "$dir" -type f -name "*.$2" -exec sed -i "s|__RAILPACK_ENV_${1}__|${replacement}|g" {} +
+}
+
+env_js="window.__ENV__ = {"
+for name in "$@"; do
+	value=$(printenv "$name" || true)
+
+	replace "$name" js "$(escape_js "$value")"
+	replace "$name" html "$(escape_html "$value")"
+	replace "$name" css "$(escape_css "$value")"
+
+	env_js="${env_js}\"${name}\": \"$(escape_js "$value")\", "
+done
+
+printf '%s};\n' "$env_js" > "$dir/env.js"
@@ -32,6 +32,11 @@ func (p *NodeProvider) isSPA(ctx *generate.GenerateContext) bool {
 		return false
 	}
 
+	// Full-stack frameworks build a server unless they are configured with a static adapter
+	if p.isServerFramework() {
+		return p.getStaticAdapterFramework(ctx) != "" && p.getOutputDirectory(ctx) != ""
+	}
+
 	isVite := p.isVite(ctx)
 	isAstro := p.isAstroSPA(ctx)
 	isCRA := p.isCRA(ctx)
@@ -46,7 +51,9 @@ func (p *NodeProvider) getSPAFramework(ctx *generate.GenerateContext) string {
 		return ""
 	}
 
-	if p.isReactRouter(ctx) {
+	if framework := p.getStaticAdapterFramework(ctx); framework != "" {
+		return framework
+	} else if p.isReactRouter(ctx) {
 		return "react-router"
 	} else if p.isVite(ctx) {
 		return "vite"
@@ -68,8 +75,25 @@ func (p *NodeProvider) DeploySPA(ctx *generate.GenerateContext, build *generate.
 	ctx.Logger.LogInfo("Deploying as %s static site", spaFramework)
 	ctx.Logger.LogInfo("Output directory: %s", outputDir)
 
+	// _headers and _redirects files are usually in the public directory, which is copied into the output
+	staticServer, err := ctx.NewStaticServer([]string{
+		path.Join(p.packageDir(), "public"),
+		path.Join(p.packageDir(), "static"),
+		p.packageDir(),
+	})
+	if err != nil {
+		return err
+	}
+	// Files with runtime variable placeholders are rewritten when the container starts
+	skipContent := ""
+	if len(p.getSPARuntimeEnv(ctx)) > 0 {
+		skipContent = SPAEnvPlaceholderPrefix
+	}
+	staticServer.AddPrecompression(ctx.GetMiseStepBuilder(), build, outputDir, skipContent)
+
 	data := map[string]interface{}{
 		"DIST_DIR": path.Join("/app", outputDir),
+		"STATIC":   staticServer,
 	}
 
 	caddyfileTemplate, err := ctx.TemplateFiles([]string{"Caddyfile.template", "Caddyfile"}, caddyfileTemplate, data)
@@ -96,10 +120,15 @@ func (p *NodeProvider) DeploySPA(ctx *generate.GenerateContext, build *generate.
 
 	ctx.Deploy.StartCmd = fmt.Sprintf("caddy run --config %s --adapter caddyfile 2>&1", DefaultCaddyfilePath)
 
+	caddyInclude := []string{DefaultCaddyfilePath}
+	if p.addSPARuntimeEnv(ctx, build, caddy, outputDir) {
+		caddyInclude = append(caddyInclude, SPAEnvScriptPath)
+	}
+
 	ctx.Deploy.AddInputs([]plan.Layer{
 		installCaddyStep.GetLayer(),
 		plan.NewStepLayer(caddy.Name(), plan.Filter{
-			Include: []string{DefaultCaddyfilePath},
+			Include: caddyInclude,
 		}),
 		plan.NewStepLayer(build.Name(), plan.Filter{
 			Include: []string{outputDir},
@@ -127,6 +156,12 @@ func (p *NodeProvider) getOutputDirectory(ctx *generate.GenerateContext) string
 
 	if dir, _ := ctx.Env.GetConfigVariable(OUTPUT_DIR_VAR); dir != "" {
 		outputDir = dir
+	} else if p.isSvelteKitStatic(ctx) {
+		outputDir = p.getSvelteKitOutputDirectory(ctx)
+	} else if p.isNitroStatic(ctx) {
+		outputDir = p.getNitroOutputDirectory(ctx)
+	} else if p.isRemixSPA(ctx) {
+		outputDir = path.Join(p.packageDir(), DefaultRemixSPAOutputDirectory)
 	} else if p.isReactRouter(ctx) {
 		outputDir = p.getReactRouterOutputDirectory(ctx)
 	} else if p.isVite(ctx) {
@@ -142,6 +177,27 @@ func (p *NodeProvider) getOutputDirectory(ctx *generate.GenerateContext) string
 	return outputDir
 }
 
+// isServerFramework checks for full-stack frameworks that build a server by default.
+// Most of them are built with Vite, but they are only deployed as static sites with a static adapter.
+func (p *NodeProvider) isServerFramework() bool {
+	return p.isSvelteKit() || p.isNitro() || p.isRemix() || p.hasDependency("@remix-run/dev")
+}
+
+// getStaticAdapterFramework returns the full-stack framework if it is configured to build a static site
+func (p *NodeProvider) getStaticAdapterFramework(ctx *generate.GenerateContext) string {
+	if p.isSvelteKitStatic(ctx) {
+		return "sveltekit"
+	} else if p.isNitroStatic(ctx) && p.isNuxt() {
+		return "nuxt"
+	} else if p.isNitroStatic(ctx) {
+		return "solid-start"
+	} else if p.isRemixSPA(ctx) {
+		return "remix"
+	}
+
+	return ""
+}
+
 func (p *NodeProvider) hasCustomStartCommand(ctx *generate.GenerateContext) bool {
 	startCommand := ctx.Config.Deploy.StartCmd
 	if startCommand == "" {
@@ -0,0 +1,97 @@
+package node
+
+import (
+	_ "embed"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+const (
+	SPA_RUNTIME_ENV_VAR = "SPA_RUNTIME_ENV"
+	SPAEnvScriptPath    = "/spa-env.sh"
+
+	SPAEnvPlaceholderPrefix = "__RAILPACK_ENV_"
+)
+
+var (
+	// spaClientEnvPrefixes are the prefixes of variables that bundlers build into the client code
+	spaClientEnvPrefixes = []string{"VITE_", "REACT_APP_", "PUBLIC_", "NUXT_PUBLIC_", "NG_APP_"}
+)
+
+//go:embed spa-env.sh
+var spaEnvScript string
+
+// getSPARuntimeEnv returns the variables that are injected into the static files when the container starts,
+// instead of being built in. They are set with a comma or space separated list of names.
+func (p *NodeProvider) getSPARuntimeEnv(ctx *generate.GenerateContext) []string {
+	value, _ := ctx.Env.GetConfigVariable(SPA_RUNTIME_ENV_VAR)
+
+	names := []string{}
+	for _, name := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// getSPABuildEnv returns the client variables that are built into the static files
+func (p *NodeProvider) getSPABuildEnv(ctx *generate.GenerateContext, runtimeEnv []string) []string {
+	names := []string{}
+	for name := range ctx.Env.Variables {
+		if slices.Contains(runtimeEnv, name) {
+			continue
+		}
+
+		for _, prefix := range spaClientEnvPrefixes {
+			if strings.HasPrefix(name, prefix) {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+func spaEnvPlaceholder(name string) string {
+	return fmt.Sprintf("%s%s__", SPAEnvPlaceholderPrefix, name)
+}
+
+// addSPARuntimeEnv builds placeholders into the static files for the runtime variables and replaces
+// them with the container environment before Caddy starts, so one image can be deployed to every environment.
+// Returns true if the runtime script was added to the caddy step.
+func (p *NodeProvider) addSPARuntimeEnv(ctx *generate.GenerateContext, build *generate.CommandStepBuilder, caddy *generate.CommandStepBuilder, outputDir string) bool {
+	runtimeEnv := p.getSPARuntimeEnv(ctx)
+
+	ctx.Metadata.Set("spaBuildVariables", strings.Join(p.getSPABuildEnv(ctx, runtimeEnv), ","))
+	ctx.Metadata.Set("spaRuntimeVariables", strings.Join(runtimeEnv, ","))
+
+	if len(runtimeEnv) == 0 {
+		return false
+	}
+
+	ctx.Logger.LogInfo("Injecting %s at runtime", strings.Join(runtimeEnv, ", "))
+
+	for _, name := range runtimeEnv {
+		// Secrets are available to every build command and take precedence over the placeholder
+		if ctx.Env.GetVariable(name) != "" {
+			ctx.Logger.LogWarn("%s is set during the build, so its build value is used instead of the runtime value", name)
+		}
+		build.AddVariables(map[string]string{name: spaEnvPlaceholder(name)})
+	}
+
+	caddy.AddCommand(plan.NewFileCommand(SPAEnvScriptPath, "spa-env.sh"))
+	caddy.Assets["spa-env.sh"] = spaEnvScript
+
+	ctx.Deploy.StartCmd = fmt.Sprintf("sh %s %s %s && %s", SPAEnvScriptPath, path.Join("/app", outputDir), strings.Join(runtimeEnv, " "), ctx.Deploy.StartCmd)
+
+	return true
+}
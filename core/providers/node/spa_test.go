@@ -1,8 +1,15 @@
 package node
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/railwayapp/railpack/core/config"
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
 	testingUtils "github.com/railwayapp/railpack/core/testing"
 	"github.com/stretchr/testify/require"
 )
@@ -160,3 +167,93 @@ func TestHasCustomStartCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestSPARuntimeEnv(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-vite-react")
+		ctx.Env.SetVariable("VITE_TITLE", "Hello")
+
+		provider := NodeProvider{}
+		require.NoError(t, provider.Initialize(ctx))
+		require.NoError(t, provider.Plan(ctx))
+
+		require.Equal(t, "caddy run --config /Caddyfile --adapter caddyfile 2>&1", ctx.Deploy.StartCmd)
+		require.Equal(t, "VITE_TITLE", ctx.Metadata.Get("spaBuildVariables"))
+		require.Empty(t, ctx.Metadata.Get("spaRuntimeVariables"))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-vite-react")
+		ctx.Env.SetVariable("RAILPACK_SPA_RUNTIME_ENV", "VITE_API_URL, VITE_SENTRY_DSN")
+		ctx.Env.SetVariable("VITE_TITLE", "Hello")
+		ctx.Env.SetVariable("REACT_APP_NAME", "app")
+		ctx.Env.SetVariable("DATABASE_URL", "postgres://localhost")
+
+		provider := NodeProvider{}
+		require.NoError(t, provider.Initialize(ctx))
+		require.NoError(t, provider.Plan(ctx))
+
+		require.Equal(t, "sh /spa-env.sh /app/dist VITE_API_URL VITE_SENTRY_DSN && caddy run --config /Caddyfile --adapter caddyfile 2>&1", ctx.Deploy.StartCmd)
+		require.Equal(t, "REACT_APP_NAME,VITE_TITLE", ctx.Metadata.Get("spaBuildVariables"))
+		require.Equal(t, "VITE_API_URL,VITE_SENTRY_DSN", ctx.Metadata.Get("spaRuntimeVariables"))
+
+		build := (*ctx.GetStepByName("build")).(*generate.CommandStepBuilder)
+		require.Equal(t, "__RAILPACK_ENV_VITE_API_URL__", build.Variables["VITE_API_URL"])
+		require.Equal(t, "__RAILPACK_ENV_VITE_SENTRY_DSN__", build.Variables["VITE_SENTRY_DSN"])
+
+		caddy := (*ctx.GetStepByName("caddy")).(*generate.CommandStepBuilder)
+		require.True(t, strings.HasPrefix(caddy.Assets["spa-env.sh"], "#!/bin/sh"))
+
+		include := ctx.Deploy.DeployInputs[1].Include
+		require.Equal(t, []string{"/Caddyfile", "/spa-env.sh"}, include)
+	})
+
+	t.Run("with precompression", func(t *testing.T) {
+		ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-vite-react")
+		ctx.Env.SetVariable("RAILPACK_SPA_RUNTIME_ENV", "VITE_API_URL")
+		ctx.Config.Static = &config.StaticConfig{Precompress: true}
+
+		provider := NodeProvider{}
+		require.NoError(t, provider.Initialize(ctx))
+		require.NoError(t, provider.Plan(ctx))
+
+		// Files with placeholders are rewritten at runtime, so compressed copies would serve the placeholders
+		build := (*ctx.GetStepByName("build")).(*generate.CommandStepBuilder)
+		precompress := build.Commands[len(build.Commands)-1].(plan.ExecCommand)
+		require.Contains(t, precompress.Cmd, `! -exec grep -q "__RAILPACK_ENV_" {} \; -exec gzip -9 -k -f {} +`)
+	})
+}
+
+func TestSPAEnvScript(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	dir := t.TempDir()
+	scriptPath := filepath.Join(dir, "spa-env.sh")
+	require.NoError(t, os.WriteFile(scriptPath, []byte(spaEnvScript), 0644))
+
+	outputDir := filepath.Join(dir, "dist")
+	require.NoError(t, os.Mkdir(outputDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "index.js"), []byte(`const a="__RAILPACK_ENV_VITE_TITLE__";const b=`+"`__RAILPACK_ENV_VITE_TITLE__`"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "index.html"), []byte("<title>__RAILPACK_ENV_VITE_TITLE__</title>"), 0644))
+
+	cmd := exec.Command("sh", scriptPath, outputDir, "VITE_TITLE")
+	cmd.Env = append(os.Environ(), "VITE_TITLE=\"Hi\" `${x}` </script>\nbye")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	escaped := `\"Hi\" \` + "`" + `\${x}\` + "`" + ` \x3c/script\x3e\nbye`
+
+	js, err := os.ReadFile(filepath.Join(outputDir, "index.js"))
+	require.NoError(t, err)
+	require.Equal(t, `const a="`+escaped+`";const b=`+"`"+escaped+"`", string(js))
+
+	html, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	require.NoError(t, err)
+	require.Equal(t, "<title>&quot;Hi&quot; `${x}` &lt;/script&gt;&#10;bye</title>", string(html))
+
+	envJs, err := os.ReadFile(filepath.Join(outputDir, "env.js"))
+	require.NoError(t, err)
+	require.Equal(t, `window.__ENV__ = {"VITE_TITLE": "`+escaped+"\", };\n", string(envJs))
+}
@@ -0,0 +1,96 @@
+package node
+
+import (
+	"path"
+	"regexp"
+
+	"github.com/railwayapp/railpack/core/generate"
+)
+
+const (
+	DefaultSvelteKitOutputDirectory = "build"
+
+	SvelteKitAdapterNode   = "node"
+	SvelteKitAdapterStatic = "static"
+	SvelteKitAdapterAuto   = "auto"
+)
+
+var (
+	// svelteKitAdapterRegex matches the adapter package imported in svelte.config.js (e.g. @sveltejs/adapter-node)
+	svelteKitAdapterRegex = regexp.MustCompile(`['"]@sveltejs/adapter-([a-z-]+)['"]`)
+
+	// svelteKitPagesRegex matches the pages option of adapter-static
+	svelteKitPagesRegex = regexp.MustCompile(`pages\s*:\s*['"](.+?)['"]`)
+
+	// svelteKitOutRegex matches the out option of adapter-node
+	svelteKitOutRegex = regexp.MustCompile(`out\s*:\s*['"](.+?)['"]`)
+)
+
+func (p *NodeProvider) isSvelteKit() bool {
+	return p.hasDependency("@sveltejs/kit")
+}
+
+// getSvelteKitAdapter returns the name of the adapter configured in svelte.config.js (e.g. node or static).
+// If the config can't be read, the installed adapter package is used.
+func (p *NodeProvider) getSvelteKitAdapter(ctx *generate.GenerateContext) string {
+	if !p.isSvelteKit() {
+		return ""
+	}
+
+	if matches := svelteKitAdapterRegex.FindStringSubmatch(p.getSvelteKitConfigFileContents(ctx)); matches != nil {
+		return matches[1]
+	}
+
+	for _, adapter := range []string{SvelteKitAdapterNode, SvelteKitAdapterStatic, SvelteKitAdapterAuto} {
+		if p.hasDependency("@sveltejs/adapter-" + adapter) {
+			return adapter
+		}
+	}
+
+	return ""
+}
+
+func (p *NodeProvider) isSvelteKitStatic(ctx *generate.GenerateContext) bool {
+	return p.getSvelteKitAdapter(ctx) == SvelteKitAdapterStatic
+}
+
+// getSvelteKitOutputDirectory returns the directory that the node or static adapter builds into
+func (p *NodeProvider) getSvelteKitOutputDirectory(ctx *generate.GenerateContext) string {
+	outputRegex := svelteKitOutRegex
+	if p.isSvelteKitStatic(ctx) {
+		outputRegex = svelteKitPagesRegex
+	}
+
+	if matches := outputRegex.FindStringSubmatch(p.getSvelteKitConfigFileContents(ctx)); matches != nil {
+		return path.Join(p.packageDir(), matches[1])
+	}
+
+	return path.Join(p.packageDir(), DefaultSvelteKitOutputDirectory)
+}
+
+// getSvelteKitStartCommand returns the command to start the server built by adapter-node
+func (p *NodeProvider) getSvelteKitStartCommand(ctx *generate.GenerateContext) string {
+	switch p.getSvelteKitAdapter(ctx) {
+	case SvelteKitAdapterNode:
+		return p.packageManager.RunScriptCommand(p.getSvelteKitOutputDirectory(ctx))
+	case SvelteKitAdapterAuto:
+		ctx.Logger.LogWarn("SvelteKit adapter-auto does not support this environment")
+		ctx.Logger.LogWarn("Use @sveltejs/adapter-node for a server or @sveltejs/adapter-static for a static site")
+	}
+
+	return ""
+}
+
+func (p *NodeProvider) getSvelteKitConfigFileContents(ctx *generate.GenerateContext) string {
+	files, err := ctx.App.FindFiles(path.Join(p.packageDir(), "svelte.config.{js,mjs,ts}"))
+	if err != nil || len(files) == 0 {
+		return ""
+	}
+
+	contents, err := ctx.App.ReadFile(files[0])
+	if err != nil {
+		return ""
+	}
+
+	return contents
+}
@@ -0,0 +1,74 @@
+package node
+
+import (
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+const (
+	TURBO_CACHE_DIR = "/app/.turbo"
+)
+
+func (p *NodeProvider) isTurbo(ctx *generate.GenerateContext) bool {
+	return ctx.App.HasFile("turbo.json")
+}
+
+// turboBuildCommand returns the turbo command to build the workspace package and the packages it depends on,
+// or every package if no workspace package is targeted
+func (p *NodeProvider) turboBuildCommand() string {
+	if p.workspacePackage != nil {
+		return "turbo run build --filter=" + p.workspacePackage.Selector()
+	}
+	return "turbo run build"
+}
+
+func (p *NodeProvider) turboVariables() map[string]string {
+	return map[string]string{"TURBO_TELEMETRY_DISABLED": "1"}
+}
+
+// shouldTurboPrune checks if turbo can prune the workspace down to the targeted package before installing.
+// Pruning is skipped when the install needs the whole app anyway.
+func (p *NodeProvider) shouldTurboPrune(ctx *generate.GenerateContext) bool {
+	return p.isTurbo(ctx) &&
+		p.workspacePackage != nil &&
+		!ctx.Dev &&
+		!p.packageManager.requiresFullSource(ctx, p.workspace)
+}
+
+// TurboPrune creates a step with only the package manifests and lock file needed to install the workspace package.
+// The install step uses this instead of every manifest in the app, so changes to unrelated packages do not invalidate it.
+func (p *NodeProvider) TurboPrune(ctx *generate.GenerateContext, miseStep *generate.MiseStepBuilder) *generate.CommandStepBuilder {
+	turboPrune := ctx.NewCommandStep("turbo-prune")
+	turboPrune.AddInput(plan.NewStepLayer(miseStep.Name()))
+	turboPrune.AddInput(plan.NewLocalLayer())
+	turboPrune.Secrets = []string{}
+	turboPrune.AddVariables(p.turboVariables())
+
+	turboPrune.AddCommands([]plan.Command{
+		plan.NewExecCommand(p.turboExecCommand() + " prune " + p.workspacePackage.Selector() + " --docker"),
+		// Replace the app with the pruned manifests. The pruned lock file is written next to the json directory.
+		plan.NewExecShellCommand(
+			`find out -maxdepth 1 -type f -exec cp {} out/json/ \; && find . -mindepth 1 -maxdepth 1 ! -name out -exec rm -rf {} + && cp -a out/json/. . && rm -rf out`,
+			plan.ExecOptions{CustomName: "use pruned package manifests"},
+		),
+	})
+
+	return turboPrune
+}
+
+// turboExecCommand returns the command to run the turbo version the app depends on.
+// Turbo is not installed yet when pruning, so it is downloaded.
+func (p *NodeProvider) turboExecCommand() string {
+	version := p.packageJson.DevDependencies["turbo"]
+	if version == "" {
+		version = p.packageJson.Dependencies["turbo"]
+	}
+	if version == "" {
+		version = "latest"
+	}
+
+	if p.packageManager == PackageManagerBun {
+		return "bunx turbo@" + version
+	}
+	return "npx --yes turbo@" + version
+}
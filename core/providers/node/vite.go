@@ -82,3 +82,8 @@ func (p *NodeProvider) getViteOutputDirectory(ctx *generate.GenerateContext) str
 func (p *NodeProvider) isSvelteKitPackage(pkg *WorkspacePackage) bool {
 	return pkg.PackageJson.hasDependency("svelte") && pkg.PackageJson.hasDependency("@sveltejs/kit")
 }
+
+// getViteConfigPort returns the server.port set in the vite config
+func (p *NodeProvider) getViteConfigPort(ctx *generate.GenerateContext) (string, string) {
+	return p.getConfigServerPort(ctx, "vite.config.{js,ts,mjs,mts,cjs}")
+}
@@ -2,7 +2,9 @@ package node
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/railwayapp/railpack/core/app"
 )
@@ -55,12 +57,33 @@ func NewWorkspace(app *app.App) (*Workspace, error) {
 
 // findWorkspacePackages finds all packages in the workspace using the workspace patterns
 func (w *Workspace) findWorkspacePackages(app *app.App) error {
+	// Patterns starting with ! exclude packages matched by the other patterns (e.g. !packages/legacy)
+	excluded := map[string]bool{}
+	for _, pattern := range w.Root.PackageJson.Workspaces {
+		if !strings.HasPrefix(pattern, "!") {
+			continue
+		}
+
+		matches, err := app.FindFiles(convertWorkspacePattern(strings.TrimPrefix(pattern, "!")))
+		if err != nil {
+			continue
+		}
+
+		for _, match := range matches {
+			excluded[match] = true
+		}
+	}
+
 	for _, pattern := range w.Root.PackageJson.Workspaces {
 		// For each workspace pattern, we need to:
 		// 1. Find all package.json files in that pattern
 		// 2. Read each package.json file
 		// 3. Add it to our list of packages
 
+		if strings.HasPrefix(pattern, "!") {
+			continue
+		}
+
 		pattern = convertWorkspacePattern(pattern)
 		matches, err := app.FindFiles(pattern)
 		if err != nil {
@@ -68,6 +91,11 @@ func (w *Workspace) findWorkspacePackages(app *app.App) error {
 		}
 
 		for _, match := range matches {
+			// A package can be matched by more than one pattern
+			if excluded[match] || w.GetPackage(filepath.Dir(match)) != nil {
+				continue
+			}
+
 			packageJson, err := readPackageJson(app, match)
 			if err != nil {
 				continue
@@ -127,6 +155,61 @@ func (w *Workspace) GetPackage(path string) *WorkspacePackage {
 	return nil
 }
 
+// FindPackage returns a workspace package by path (e.g. apps/api) or package name
+func (w *Workspace) FindPackage(pathOrName string) *WorkspacePackage {
+	cleanPath := strings.TrimPrefix(path.Clean(pathOrName), "./")
+	if pkg := w.GetPackage(cleanPath); pkg != nil {
+		return pkg
+	}
+
+	for _, pkg := range w.Packages {
+		if pkg.PackageJson.Name != "" && pkg.PackageJson.Name == pathOrName {
+			return pkg
+		}
+	}
+
+	return nil
+}
+
+// LocalDependencies returns the workspace packages that pkg depends on, directly or transitively.
+// Every package comes after its own dependencies, so they can be built in order.
+func (w *Workspace) LocalDependencies(pkg *WorkspacePackage) []*WorkspacePackage {
+	byName := map[string]*WorkspacePackage{}
+	for _, p := range w.Packages {
+		if p.PackageJson.Name != "" {
+			byName[p.PackageJson.Name] = p
+		}
+	}
+
+	deps := []*WorkspacePackage{}
+	visited := map[string]bool{pkg.Path: true}
+
+	var visit func(current *WorkspacePackage)
+	visit = func(current *WorkspacePackage) {
+		for _, name := range current.PackageJson.dependencyNames() {
+			dep, ok := byName[name]
+			if !ok || visited[dep.Path] {
+				continue
+			}
+			visited[dep.Path] = true
+
+			visit(dep)
+			deps = append(deps, dep)
+		}
+	}
+	visit(pkg)
+
+	return deps
+}
+
+// Selector returns how the package is selected in package manager workspace commands
+func (p *WorkspacePackage) Selector() string {
+	if p.PackageJson.Name != "" {
+		return p.PackageJson.Name
+	}
+	return "./" + p.Path
+}
+
 func (w *Workspace) HasDependency(dependency string) bool {
 	if w.Root.PackageJson.hasDependency(dependency) {
 		return true
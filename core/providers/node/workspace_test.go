@@ -146,3 +146,21 @@ func TestWorkspaceAllPackageJson(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceLocalDependencies(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/node-pnpm-workspace-package")
+	workspace, err := NewWorkspace(ctx.App)
+	require.NoError(t, err)
+
+	api := workspace.FindPackage("apps/api")
+	require.NotNil(t, api)
+	require.Equal(t, api, workspace.FindPackage("./apps/api/"))
+	require.Equal(t, api, workspace.FindPackage("@acme/api"))
+	require.Nil(t, workspace.FindPackage("apps/missing"))
+
+	deps := workspace.LocalDependencies(api)
+	require.Len(t, deps, 1)
+	require.Equal(t, "packages/shared", deps[0].Path)
+
+	require.Empty(t, workspace.LocalDependencies(workspace.FindPackage("apps/web")))
+}
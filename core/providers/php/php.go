@@ -107,9 +107,7 @@ func (p *PhpProvider) Plan(ctx *generate.GenerateContext) error {
 		// Add development environment variables
 		ctx.Deploy.Variables = p.getPhpDevEnvVars(ctx)
 		// Add required port for web applications
-		if port := p.getDevPort(ctx); port != "" {
-			ctx.Deploy.RequiredPort = port
-		}
+		ctx.SetRequiredPort(p.getDevPort(ctx))
 	} else {
 		// Add production environment variables
 		ctx.Deploy.Variables = p.getPhpProdEnvVars(ctx)
@@ -499,10 +497,14 @@ func (p *PhpProvider) getDevStartCmdHost(ctx *generate.GenerateContext) string {
 	return "php -S 0.0.0.0:8000 -t ."
 }
 
-// getDevPort returns the appropriate port for development mode based on framework
-func (p *PhpProvider) getDevPort(ctx *generate.GenerateContext) string {
+// getDevPort returns the port for development mode and where it was found
+func (p *PhpProvider) getDevPort(ctx *generate.GenerateContext) (string, string) {
+	if port := generate.PortFromCommand(p.getDevStartCmd(ctx)); port != "" {
+		return port, "start command"
+	}
+
 	// All PHP web applications use port 8000 by default
-	return "8000"
+	return "8000", generate.PortSourceDefault
 }
 
 // getPhpDevEnvVars returns development-specific environment variables
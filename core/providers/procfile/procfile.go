@@ -21,6 +21,12 @@ func (p *ProcfileProvider) Plan(ctx *generate.GenerateContext) (bool, error) {
 	webCommand := parsedProcfile["web"]
 	workerCommand := parsedProcfile["worker"]
 
+	if releaseCommand := parsedProcfile["release"]; releaseCommand != "" {
+		ctx.Logger.LogInfo("Found release command in Procfile")
+		ctx.Deploy.ReleaseCmd = releaseCommand
+		delete(parsedProcfile, "release")
+	}
+
 	if webCommand != "" {
 		ctx.Logger.LogInfo("Found web command in Procfile")
 		ctx.Deploy.StartCmd = webCommand
@@ -16,3 +16,16 @@ func TestProcfile(t *testing.T) {
 
 	require.Equal(t, "gunicorn --bind 0.0.0.0:3333 main:app", ctx.Deploy.StartCmd)
 }
+
+func TestProcfileRelease(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContextFromFiles(t, map[string]string{
+		"Procfile": "release: python manage.py migrate\nweb: gunicorn mysite.wsgi\n",
+	})
+	provider := ProcfileProvider{}
+
+	_, err := provider.Plan(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, "gunicorn mysite.wsgi", ctx.Deploy.StartCmd)
+	require.Equal(t, "python manage.py migrate", ctx.Deploy.ReleaseCmd)
+}
@@ -0,0 +1,232 @@
+package python
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/generate"
+)
+
+const (
+	AppInterfaceASGI = "asgi"
+	AppInterfaceWSGI = "wsgi"
+)
+
+var (
+	// appAssignmentRegex matches module level assignments like `app = FastAPI()` or `app: Flask = Flask(__name__)`
+	appAssignmentRegex = regexp.MustCompile(`(?m)^([A-Za-z_][A-Za-z0-9_]*)\s*(?::\s*[A-Za-z0-9_.\[\]]+\s*)?=\s*([A-Za-z0-9_.]+)\(`)
+
+	// fastAppRegex matches the FastHTML shortcut `app, rt = fast_app()`
+	fastAppRegex = regexp.MustCompile(`(?m)^([A-Za-z_][A-Za-z0-9_]*)\s*,\s*[A-Za-z_][A-Za-z0-9_]*\s*=\s*fast_app\(`)
+
+	// appFactoryRegex matches application factories like `def create_app():`
+	appFactoryRegex = regexp.MustCompile(`(?m)^(?:async\s+)?def\s+(create_app|make_app|app_factory)\s*\(`)
+
+	pythonIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// appConstructors maps the callables that create an application object to the framework and interface
+	appConstructors = map[string][2]string{
+		"FastAPI":              {"fastapi", AppInterfaceASGI},
+		"Starlette":            {"starlette", AppInterfaceASGI},
+		"FastHTML":             {"fasthtml", AppInterfaceASGI},
+		"Quart":                {"quart", AppInterfaceASGI},
+		"Litestar":             {"litestar", AppInterfaceASGI},
+		"get_asgi_application": {"django", AppInterfaceASGI},
+		"Flask":                {"flask", AppInterfaceWSGI},
+		"get_wsgi_application": {"django", AppInterfaceWSGI},
+	}
+
+	// Files are preferred in this order when several modules define an application
+	preferredAppFiles = []string{"main.py", "app.py", "server.py", "api.py", "__init__.py", "wsgi.py", "asgi.py"}
+
+	ignoredAppDirs = []string{".venv", "venv", "env", "site-packages", "node_modules", "tests", "test", "migrations", "__pycache__"}
+)
+
+// pythonAppObject is an ASGI or WSGI application found in the source
+type pythonAppObject struct {
+	File      string
+	Module    string
+	Attr      string
+	Framework string
+	Interface string
+	Factory   bool
+
+	// AppDir is the directory the module is imported from (e.g. src)
+	AppDir string
+}
+
+// Target returns the module:attr reference used by gunicorn and Flask. Factories are called.
+func (a *pythonAppObject) Target() string {
+	return fmt.Sprintf("%s:%s", a.Module, a.attrRef())
+}
+
+func (a *pythonAppObject) attrRef() string {
+	if a.Factory {
+		return a.Attr + "()"
+	}
+	return a.Attr
+}
+
+// UvicornArgs returns the arguments that point uvicorn at the application
+func (a *pythonAppObject) UvicornArgs() string {
+	args := []string{}
+	if a.Factory {
+		args = append(args, "--factory")
+	}
+	if a.AppDir != "" {
+		args = append(args, "--app-dir", a.AppDir)
+	}
+
+	return strings.Join(append(args, fmt.Sprintf("%s:%s", a.Module, a.Attr)), " ")
+}
+
+// GunicornArgs returns the arguments that point gunicorn at the application
+func (a *pythonAppObject) GunicornArgs() string {
+	if a.AppDir != "" {
+		return fmt.Sprintf("--pythonpath %s %s", a.AppDir, a.quote(a.Target()))
+	}
+	return a.quote(a.Target())
+}
+
+// FlaskApp returns the value of flask --app. Modules outside of the root are referenced by path
+// so that Flask can add their directory to the import path.
+func (a *pythonAppObject) FlaskApp() string {
+	if a.AppDir != "" {
+		return fmt.Sprintf("%s:%s", a.File, a.attrRef())
+	}
+	return a.Target()
+}
+
+// quote quotes factory calls so the parentheses are not parsed by the shell
+func (a *pythonAppObject) quote(target string) string {
+	if a.Factory {
+		return fmt.Sprintf(`"%s"`, target)
+	}
+	return target
+}
+
+// detectAppObject scans the Python files for an ASGI or WSGI application.
+// Files closer to the root (or to src/) are preferred.
+func detectAppObject(ctx *generate.GenerateContext) *pythonAppObject {
+	files, err := ctx.App.FindFiles("**/*.py")
+	if err != nil {
+		return nil
+	}
+
+	candidates := []*pythonAppObject{}
+	for _, file := range files {
+		if !isAppCandidateFile(file) {
+			continue
+		}
+
+		contents, err := ctx.App.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		if app := findAppObject(file, contents); app != nil {
+			candidates = append(candidates, app)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(candidates, func(a, b *pythonAppObject) int {
+		if depth := strings.Count(a.Module, ".") - strings.Count(b.Module, "."); depth != 0 {
+			return depth
+		}
+		if rank := appFileRank(a.File) - appFileRank(b.File); rank != 0 {
+			return rank
+		}
+		if a.Factory != b.Factory {
+			if a.Factory {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.File, b.File)
+	})
+
+	return candidates[0]
+}
+
+// findAppObject returns the application defined in a file, preferring module level objects over factories
+func findAppObject(file string, contents string) *pythonAppObject {
+	appDir := ""
+	modulePath := strings.TrimSuffix(file, ".py")
+	if strings.HasPrefix(modulePath, "src/") {
+		appDir = "src"
+		modulePath = strings.TrimPrefix(modulePath, "src/")
+	}
+	modulePath = strings.TrimSuffix(strings.TrimSuffix(modulePath, "__init__"), "/")
+	if modulePath == "" {
+		return nil
+	}
+
+	app := &pythonAppObject{
+		File:   file,
+		Module: strings.ReplaceAll(modulePath, "/", "."),
+		AppDir: appDir,
+	}
+
+	for _, match := range appAssignmentRegex.FindAllStringSubmatch(contents, -1) {
+		callee := match[2][strings.LastIndex(match[2], ".")+1:]
+		if constructor, ok := appConstructors[callee]; ok {
+			app.Attr = match[1]
+			app.Framework, app.Interface = constructor[0], constructor[1]
+			return app
+		}
+	}
+
+	if match := fastAppRegex.FindStringSubmatch(contents); match != nil {
+		app.Attr = match[1]
+		app.Framework, app.Interface = "fasthtml", AppInterfaceASGI
+		return app
+	}
+
+	if match := appFactoryRegex.FindStringSubmatch(contents); match != nil {
+		// The framework of a factory is found from what the file uses
+		for _, callee := range []string{"FastAPI", "Starlette", "Quart", "Litestar", "Flask"} {
+			if strings.Contains(contents, callee+"(") {
+				app.Attr = match[1]
+				app.Framework, app.Interface = appConstructors[callee][0], appConstructors[callee][1]
+				app.Factory = true
+				return app
+			}
+		}
+	}
+
+	return nil
+}
+
+// isAppCandidateFile returns true if the file can be imported as a module and is not a test or dependency
+func isAppCandidateFile(file string) bool {
+	dirs := strings.Split(path.Dir(file), "/")
+	for _, dir := range dirs {
+		if dir == "." {
+			continue
+		}
+		if slices.Contains(ignoredAppDirs, dir) || !pythonIdentifierRegex.MatchString(dir) {
+			return false
+		}
+	}
+
+	name := strings.TrimSuffix(path.Base(file), ".py")
+	if strings.HasPrefix(name, "test_") || strings.HasSuffix(name, "_test") || name == "conftest" || name == "setup" {
+		return false
+	}
+
+	return pythonIdentifierRegex.MatchString(name)
+}
+
+func appFileRank(file string) int {
+	if index := slices.Index(preferredAppFiles, path.Base(file)); index != -1 {
+		return index
+	}
+	return len(preferredAppFiles)
+}
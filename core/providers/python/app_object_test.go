@@ -0,0 +1,159 @@
+package python
+
+import (
+	"testing"
+
+	testingUtils "github.com/railwayapp/railpack/core/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAppObject(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		contents  string
+		target    string
+		uvicorn   string
+		framework string
+		iface     string
+	}{
+		{
+			name:      "fastapi",
+			file:      "main.py",
+			contents:  "from fastapi import FastAPI\n\napp = FastAPI()\n",
+			target:    "main:app",
+			uvicorn:   "main:app",
+			framework: "fastapi",
+			iface:     AppInterfaceASGI,
+		},
+		{
+			name:      "annotated flask",
+			file:      "server.py",
+			contents:  "import flask\n\nserver: flask.Flask = flask.Flask(__name__)\n",
+			target:    "server:server",
+			uvicorn:   "server:server",
+			framework: "flask",
+			iface:     AppInterfaceWSGI,
+		},
+		{
+			name:      "starlette package",
+			file:      "myapp/__init__.py",
+			contents:  "from starlette.applications import Starlette\n\napi = Starlette(routes=routes)\n",
+			target:    "myapp:api",
+			uvicorn:   "myapp:api",
+			framework: "starlette",
+			iface:     AppInterfaceASGI,
+		},
+		{
+			name:      "fasthtml",
+			file:      "app.py",
+			contents:  "from fasthtml.common import *\n\napp, rt = fast_app()\n",
+			target:    "app:app",
+			uvicorn:   "app:app",
+			framework: "fasthtml",
+			iface:     AppInterfaceASGI,
+		},
+		{
+			name:      "flask factory in src layout",
+			file:      "src/blog/__init__.py",
+			contents:  "from flask import Flask\n\ndef create_app(config=None):\n    app = Flask(__name__)\n    return app\n",
+			target:    "blog:create_app()",
+			uvicorn:   "--factory --app-dir src blog:create_app",
+			framework: "flask",
+			iface:     AppInterfaceWSGI,
+		},
+		{
+			name:      "django asgi",
+			file:      "mysite/asgi.py",
+			contents:  "from django.core.asgi import get_asgi_application\n\napplication = get_asgi_application()\n",
+			target:    "mysite.asgi:application",
+			uvicorn:   "mysite.asgi:application",
+			framework: "django",
+			iface:     AppInterfaceASGI,
+		},
+		{
+			name:     "no app",
+			file:     "main.py",
+			contents: "print('hello')\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := findAppObject(tt.file, tt.contents)
+			if tt.target == "" {
+				require.Nil(t, app)
+				return
+			}
+
+			require.NotNil(t, app)
+			require.Equal(t, tt.target, app.Target())
+			require.Equal(t, tt.uvicorn, app.UvicornArgs())
+			require.Equal(t, tt.framework, app.Framework)
+			require.Equal(t, tt.iface, app.Interface)
+		})
+	}
+}
+
+func TestAppObjectStartCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		startCmd string
+		devCmd   string
+	}{
+		{
+			name: "fastapi in src layout",
+			files: map[string]string{
+				"requirements.txt":    "fastapi\nuvicorn\n",
+				"src/api/__init__.py": "",
+				"src/api/main.py":     "from fastapi import FastAPI\n\napp = FastAPI()\n",
+				"tests/test_main.py":  "from fastapi import FastAPI\n\ntest_app = FastAPI()\n",
+			},
+			startCmd: "uvicorn --app-dir src api.main:app --host 0.0.0.0 --port ${PORT:-8000}",
+			devCmd:   "/app/.venv/bin/uvicorn --app-dir src api.main:app --reload --host 0.0.0.0 --port 8000",
+		},
+		{
+			name: "flask factory",
+			files: map[string]string{
+				"requirements.txt":    "flask\ngunicorn\n",
+				"blog/__init__.py":    "from flask import Flask\n\ndef create_app():\n    return Flask(__name__)\n",
+				"blog/views/index.py": "from flask import Blueprint\n\nbp = Blueprint('index', __name__)\n",
+			},
+			startCmd: `gunicorn --bind 0.0.0.0:${PORT:-8000} "blog:create_app()"`,
+			devCmd:   `/app/.venv/bin/flask --app "blog:create_app()" run --host 0.0.0.0 --port 5000`,
+		},
+		{
+			name: "starlette app module",
+			files: map[string]string{
+				"requirements.txt": "starlette\nuvicorn\n",
+				"main.py":          "print('not the app')\n",
+				"service/app.py":   "from starlette.applications import Starlette\n\napplication = Starlette()\n",
+			},
+			startCmd: "uvicorn service.app:application --host 0.0.0.0 --port ${PORT:-8000}",
+			devCmd:   "/app/.venv/bin/uvicorn service.app:application --reload --host 0.0.0.0 --port 8000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContextFromFiles(t, tt.files)
+			provider := PythonProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+
+			require.Equal(t, tt.startCmd, provider.GetStartCommand(ctx))
+			require.Equal(t, tt.devCmd, provider.GetDevStartCommand(ctx))
+		})
+	}
+}
+
+func TestAppObjectMetadata(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/python-fastapi-src")
+	provider := PythonProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Equal(t, "api.main:app", ctx.Metadata.Get("pythonAppObject"))
+	require.Equal(t, "asgi", ctx.Metadata.Get("pythonAppInterface"))
+	require.Equal(t, "src/api/main.py", ctx.Metadata.Get("pythonAppFile"))
+}
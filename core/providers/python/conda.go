@@ -0,0 +1,113 @@
+package python
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+const (
+	CONDA_ENV_PATH   = "/opt/conda"
+	CONDA_PKGS_DIR   = "/opt/conda-pkgs"
+	PIXI_ENV_PATH    = "/app/.pixi/envs/default"
+	PIXI_CACHE_DIR   = "/opt/pixi-cache"
+	PIXI_MANIFEST    = "pixi.toml"
+	PIXI_LOCK_FILE   = "pixi.lock"
+	CONDA_CACHE_NAME = "conda"
+	PIXI_CACHE_NAME  = "pixi"
+)
+
+var condaEnvironmentFiles = []string{"environment.yml", "environment.yaml"}
+
+// InstallConda creates the environment from environment.yml with micromamba. The packages are
+// copied from the cache, as they cannot be hard linked from the cache mount.
+func (p *PythonProvider) InstallConda(ctx *generate.GenerateContext, install *generate.CommandStepBuilder) []string {
+	ctx.Logger.LogInfo("Using micromamba")
+
+	environmentFile := p.getCondaEnvironmentFile(ctx)
+
+	install.AddCache(ctx.Caches.AddCache(CONDA_CACHE_NAME, CONDA_PKGS_DIR))
+	install.AddEnvVars(p.GetPythonEnvVars(ctx))
+	install.AddEnvVars(map[string]string{
+		"CONDA_PKGS_DIRS":   CONDA_PKGS_DIR,
+		"MAMBA_ROOT_PREFIX": "/opt/micromamba",
+		"MAMBA_NO_BANNER":   "1",
+		"MAMBA_ALWAYS_COPY": "true",
+	})
+
+	// pip dependencies in the environment can reference local files
+	if contents, err := ctx.App.ReadFile(environmentFile); err == nil && referencesLocalFiles(contents) {
+		install.AddInput(plan.NewLocalLayer())
+	} else {
+		install.AddCommand(plan.NewCopyCommand(environmentFile))
+	}
+
+	install.AddCommands([]plan.Command{
+		plan.NewPathCommand(CONDA_ENV_PATH + "/bin"),
+		plan.NewExecCommand(fmt.Sprintf("micromamba create -y -p %s -f %s", CONDA_ENV_PATH, environmentFile)),
+	})
+
+	return []string{CONDA_ENV_PATH}
+}
+
+// InstallPixi installs the default environment of a pixi project into .pixi/envs/default
+func (p *PythonProvider) InstallPixi(ctx *generate.GenerateContext, install *generate.CommandStepBuilder) []string {
+	ctx.Logger.LogInfo("Using pixi")
+
+	install.AddCache(ctx.Caches.AddCache(PIXI_CACHE_NAME, PIXI_CACHE_DIR))
+	install.AddEnvVars(p.GetPythonEnvVars(ctx))
+	install.AddEnvVars(map[string]string{
+		"PIXI_CACHE_DIR": PIXI_CACHE_DIR,
+	})
+
+	// path dependencies require the project source
+	if contents, err := ctx.App.ReadFile(PIXI_MANIFEST); err == nil && referencesLocalFiles(contents) {
+		install.AddInput(plan.NewLocalLayer())
+	} else {
+		for _, file := range []string{PIXI_MANIFEST, PIXI_LOCK_FILE, "pyproject.toml"} {
+			if ctx.App.HasFile(file) {
+				install.AddCommand(plan.NewCopyCommand(file))
+			}
+		}
+	}
+
+	installCmd := "pixi install"
+	if ctx.App.HasFile(PIXI_LOCK_FILE) {
+		installCmd = "pixi install --locked"
+	}
+
+	install.AddCommands([]plan.Command{
+		plan.NewPathCommand(PIXI_ENV_PATH + "/bin"),
+		plan.NewExecCommand(installCmd),
+	})
+
+	return []string{strings.TrimPrefix(PIXI_ENV_PATH, "/app/")}
+}
+
+func (p *PythonProvider) getCondaEnvironmentFile(ctx *generate.GenerateContext) string {
+	for _, file := range condaEnvironmentFiles {
+		if ctx.App.HasFile(file) {
+			return file
+		}
+	}
+	return ""
+}
+
+func (p *PythonProvider) hasConda(ctx *generate.GenerateContext) bool {
+	return p.getCondaEnvironmentFile(ctx) != ""
+}
+
+func (p *PythonProvider) hasPixi(ctx *generate.GenerateContext) bool {
+	return ctx.App.HasFile(PIXI_MANIFEST)
+}
+
+// usesCondaEnvironment returns true if the dependencies and Python are installed from conda packages
+func (p *PythonProvider) usesCondaEnvironment(ctx *generate.GenerateContext) bool {
+	return p.hasPixi(ctx) || p.hasConda(ctx)
+}
+
+func referencesLocalFiles(contents string) bool {
+	return strings.Contains(contents, "file://") || strings.Contains(contents, "path = ") || strings.Contains(contents, "-e .")
+}
@@ -0,0 +1,69 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+	testingUtils "github.com/railwayapp/railpack/core/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCondaEnvironments(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		packageManager string
+		installCmd     string
+		envPath        string
+		installOutput  string
+		cache          string
+	}{
+		{
+			name:           "conda",
+			path:           "../../../examples/python-conda",
+			packageManager: "conda",
+			installCmd:     "micromamba create -y -p /opt/conda -f environment.yml",
+			envPath:        "/opt/conda",
+			installOutput:  "/opt/conda",
+			cache:          "conda",
+		},
+		{
+			name:           "pixi",
+			path:           "../../../examples/python-pixi",
+			packageManager: "pixi",
+			installCmd:     "pixi install",
+			envPath:        "/app/.pixi/envs/default",
+			installOutput:  ".pixi/envs/default",
+			cache:          "pixi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContext(t, tt.path)
+			provider := PythonProvider{}
+
+			detected, err := provider.Detect(ctx)
+			require.NoError(t, err)
+			require.True(t, detected)
+
+			require.NoError(t, provider.Initialize(ctx))
+			require.NoError(t, provider.Plan(ctx))
+
+			require.Equal(t, tt.packageManager, ctx.Metadata.Get("pythonPackageManager"))
+			require.Equal(t, tt.envPath+"/bin/python main.py", ctx.Deploy.StartCmd)
+			require.Contains(t, ctx.Deploy.Paths, tt.envPath+"/bin")
+
+			install := (*ctx.GetStepByName("install")).(*generate.CommandStepBuilder)
+			require.Contains(t, install.Commands, plan.NewExecCommand(tt.installCmd))
+			require.Contains(t, install.Caches, tt.cache)
+
+			require.Equal(t, []string{tt.installOutput}, ctx.Deploy.DeployInputs[1].Include)
+
+			// Python and the native libraries come from the environment
+			require.NotContains(t, ctx.GetMiseStepBuilder().SupportingAptPackages, "python3-dev")
+			require.Empty(t, ctx.Deploy.AptPackages)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package python
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/generate"
+)
+
+const JUPYTER_TOKEN_VAR = "JUPYTER_TOKEN"
+
+// getDataAppStartCommand returns the production start command of Streamlit and Gradio apps, which run the main Python file.
+// The servers listen on all interfaces and on $PORT, falling back to the default port of each framework.
+// Frameworks that are only dev dependencies are not installed in production, so they are not started.
+func (p *PythonProvider) getDataAppStartCommand(ctx *generate.GenerateContext) string {
+	mainPythonFile := p.getMainPythonFile(ctx)
+
+	if p.usesMainDep(ctx, "streamlit") && strings.HasSuffix(mainPythonFile, ".py") {
+		return fmt.Sprintf("%s run %s --server.address 0.0.0.0 --server.port ${PORT:-8501} --server.headless true", p.binCommand(ctx, "streamlit"), mainPythonFile)
+	}
+
+	// Gradio reads the port from GRADIO_SERVER_PORT, which is set from $PORT when the container starts
+	if p.usesMainDep(ctx, "gradio") && strings.HasSuffix(mainPythonFile, ".py") {
+		return fmt.Sprintf("GRADIO_SERVER_PORT=${PORT:-7860} %s %s", p.binCommand(ctx, "python"), mainPythonFile)
+	}
+
+	return ""
+}
+
+// getNotebookStartCommand returns the production start command of Jupyter and Voila apps. It is only used
+// when no other entry point is found, as a notebook server gives access to the whole app.
+func (p *PythonProvider) getNotebookStartCommand(ctx *generate.GenerateContext) string {
+	// Voila renders notebooks as read-only apps, so it does not need a token
+	if p.usesMainDep(ctx, "voila") {
+		notebook := ""
+		if file := p.getNotebookFile(ctx); file != "" {
+			notebook = file + " "
+		}
+		return fmt.Sprintf("%s %s--no-browser --Voila.ip=0.0.0.0 --port=${PORT:-8866}", p.binCommand(ctx, "voila"), notebook)
+	}
+
+	if p.usesMainDep(ctx, "jupyter") || p.usesMainDep(ctx, "notebook") || p.usesMainDep(ctx, "jupyterlab") {
+		return p.jupyterServerCommand(ctx)
+	}
+
+	return ""
+}
+
+// getNotebookFile returns the notebook Voila renders. Without a known name, a single notebook in the root is used
+// and otherwise Voila lists the notebooks of the directory.
+func (p *PythonProvider) getNotebookFile(ctx *generate.GenerateContext) string {
+	for _, file := range []string{"notebook.ipynb", "main.ipynb", "app.ipynb", "analysis.ipynb"} {
+		if ctx.App.HasFile(file) {
+			return file
+		}
+	}
+
+	if notebooks, err := ctx.App.FindFiles("*.ipynb"); err == nil && len(notebooks) == 1 {
+		return notebooks[0]
+	}
+
+	return ""
+}
+
+// jupyterServerCommand starts Jupyter Server, which serves the installed frontends (e.g. JupyterLab).
+// The token is read from JUPYTER_TOKEN.
+func (p *PythonProvider) jupyterServerCommand(ctx *generate.GenerateContext) string {
+	return fmt.Sprintf("%s server --ip 0.0.0.0 --port ${PORT:-8888} --no-browser --allow-root", p.binCommand(ctx, "jupyter"))
+}
+
+// warnJupyterToken warns if Jupyter Server will generate a random token because JUPYTER_TOKEN is not set
+func (p *PythonProvider) warnJupyterToken(ctx *generate.GenerateContext, startCmd string) {
+	if startCmd != p.jupyterServerCommand(ctx) {
+		return
+	}
+
+	if ctx.Env.GetVariable(JUPYTER_TOKEN_VAR) == "" {
+		ctx.Logger.LogWarn("Set the %s secret to choose the token used to access Jupyter. Otherwise a random token is printed in the deploy logs", JUPYTER_TOKEN_VAR)
+	}
+}
+
+// getDataAppEnvVars returns the runtime variables of Streamlit and Gradio apps in production
+func (p *PythonProvider) getDataAppEnvVars(ctx *generate.GenerateContext) map[string]string {
+	envVars := map[string]string{}
+
+	if p.isStreamlit(ctx) {
+		envVars["STREAMLIT_SERVER_HEADLESS"] = "true"
+		envVars["STREAMLIT_BROWSER_GATHER_USAGE_STATS"] = "false"
+	} else if p.isGradio(ctx) {
+		envVars["GRADIO_SERVER_NAME"] = "0.0.0.0"
+		envVars["GRADIO_ANALYTICS_ENABLED"] = "False"
+	}
+
+	return envVars
+}
+
+// binCommand returns the command that runs an executable of the environment
+func (p *PythonProvider) binCommand(ctx *generate.GenerateContext, name string) string {
+	if p.hasPoetry(ctx) {
+		return "poetry run " + name
+	}
+	return fmt.Sprintf("%s/bin/%s", p.GetVenvPath(ctx), name)
+}
@@ -2,46 +2,139 @@ package python
 
 import (
 	"fmt"
+	"path"
 	"regexp"
+	"strings"
 
 	"github.com/railwayapp/railpack/core/generate"
 )
 
+var (
+	djangoWsgiRegex           = regexp.MustCompile(`WSGI_APPLICATION = ["'](.*).application["']`)
+	djangoAsgiRegex           = regexp.MustCompile(`ASGI_APPLICATION\s*=\s*["']([A-Za-z0-9_.]+)\.([A-Za-z0-9_]+)["']`)
+	djangoSettingsModuleRegex = regexp.MustCompile(`DJANGO_SETTINGS_MODULE["']\s*,\s*["']([A-Za-z0-9_.]+)["']`)
+	djangoStaticRootRegex     = regexp.MustCompile(`(?m)^STATIC_ROOT\s*=`)
+)
+
 func (p *PythonProvider) getDjangoAppName(ctx *generate.GenerateContext) string {
 	if appName, _ := ctx.Env.GetConfigVariable("DJANGO_APP_NAME"); appName != "" {
 		return appName
 	}
 
-	paths, err := ctx.App.FindFiles("**/*.py")
-	if err != nil {
-		return ""
+	if matches := p.findInDjangoFiles(ctx, djangoWsgiRegex); len(matches) > 1 {
+		return matches[1]
 	}
 
-	re := regexp.MustCompile(`WSGI_APPLICATION = ["'](.*).application["']`)
+	if app := p.appObject; app != nil && app.Framework == "django" && app.Interface == AppInterfaceWSGI {
+		return app.Module
+	}
 
-	for _, path := range paths {
-		contents, err := ctx.App.ReadFile(path)
+	return ""
+}
+
+// getDjangoAsgiApplication returns the module and attribute of the ASGI_APPLICATION setting
+func (p *PythonProvider) getDjangoAsgiApplication(ctx *generate.GenerateContext) (string, string) {
+	if matches := p.findInDjangoFiles(ctx, djangoAsgiRegex); len(matches) > 2 {
+		return matches[1], matches[2]
+	}
+
+	return "", ""
+}
+
+// getDjangoSettingsModule returns the settings module that manage.py or the WSGI/ASGI modules set as the default
+func (p *PythonProvider) getDjangoSettingsModule(ctx *generate.GenerateContext) string {
+	files := []string{"manage.py"}
+	for _, pattern := range []string{"**/wsgi.py", "**/asgi.py"} {
+		if matches, err := ctx.App.FindFiles(pattern); err == nil {
+			files = append(files, matches...)
+		}
+	}
+
+	for _, file := range files {
+		if file != "manage.py" && !isAppCandidateFile(file) {
+			continue
+		}
+
+		contents, err := ctx.App.ReadFile(file)
 		if err != nil {
 			continue
 		}
 
-		matches := re.FindStringSubmatch(contents)
-		if len(matches) > 1 {
+		if matches := djangoSettingsModuleRegex.FindStringSubmatch(contents); len(matches) > 1 {
 			return matches[1]
 		}
 	}
 
+	// The settings usually live next to the WSGI module (e.g. mysite.wsgi and mysite.settings)
+	if appName := p.getDjangoAppName(ctx); strings.Contains(appName, ".") {
+		settingsModule := appName[:strings.LastIndex(appName, ".")] + ".settings"
+		settingsPath := strings.ReplaceAll(settingsModule, ".", "/")
+		if ctx.App.HasFile(settingsPath+".py") || ctx.App.HasFile(path.Join(settingsPath, "__init__.py")) {
+			return settingsModule
+		}
+	}
+
 	return ""
 }
 
 func (p *PythonProvider) getDjangoStartCommand(ctx *generate.GenerateContext) string {
+	if module, attr := p.getDjangoAsgiApplication(ctx); module != "" {
+		if p.usesDep(ctx, "daphne") {
+			ctx.Logger.LogInfo("Using Django ASGI app with daphne: %s", module)
+			return fmt.Sprintf("daphne -b 0.0.0.0 -p ${PORT:-8000} %s:%s", module, attr)
+		}
+
+		if p.usesDep(ctx, "uvicorn") {
+			ctx.Logger.LogInfo("Using Django ASGI app with uvicorn: %s", module)
+			return fmt.Sprintf("uvicorn %s:%s --host 0.0.0.0 --port ${PORT:-8000}", module, attr)
+		}
+	}
+
 	appName := p.getDjangoAppName(ctx)
 	if appName == "" {
 		return ""
 	}
 
 	ctx.Logger.LogInfo("Using Django app: %s", appName)
-	return fmt.Sprintf("python manage.py migrate && gunicorn %s:application", appName)
+	return fmt.Sprintf("gunicorn %s:application", appName)
+}
+
+// getDjangoReleaseCommand returns the command that runs once per deployment instead of on every replica start
+func (p *PythonProvider) getDjangoReleaseCommand(ctx *generate.GenerateContext) string {
+	commands := []string{"python manage.py migrate"}
+
+	// collectstatic fails if STATIC_ROOT is not set
+	if matches := p.findInDjangoFiles(ctx, djangoStaticRootRegex); matches != nil {
+		commands = append(commands, "python manage.py collectstatic --noinput")
+	}
+
+	return strings.Join(commands, " && ")
+}
+
+// findInDjangoFiles returns the first match of the regex in the Python files of the project.
+// Dependencies are skipped, as Django's own global_settings.py sets STATIC_ROOT.
+func (p *PythonProvider) findInDjangoFiles(ctx *generate.GenerateContext, re *regexp.Regexp) []string {
+	paths, err := ctx.App.FindFiles("**/*.py")
+	if err != nil {
+		return nil
+	}
+
+	for _, path := range paths {
+		if !isAppCandidateFile(path) {
+			continue
+		}
+
+		contents, err := ctx.App.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		if matches := re.FindStringSubmatch(contents); matches != nil {
+			return matches
+		}
+	}
+
+	return nil
 }
 
 func (p *PythonProvider) isDjango(ctx *generate.GenerateContext) bool {
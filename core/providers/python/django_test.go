@@ -9,16 +9,20 @@ import (
 
 func TestDjango(t *testing.T) {
 	tests := []struct {
-		name     string
-		path     string
-		appName  string
-		startCmd string
+		name           string
+		path           string
+		appName        string
+		settingsModule string
+		startCmd       string
+		releaseCmd     string
 	}{
 		{
-			name:     "django project",
-			path:     "../../../examples/python-django",
-			appName:  "mysite.wsgi",
-			startCmd: "python manage.py migrate && gunicorn mysite.wsgi:application",
+			name:           "django project",
+			path:           "../../../examples/python-django",
+			appName:        "mysite.wsgi",
+			settingsModule: "mysite.settings",
+			startCmd:       "gunicorn mysite.wsgi:application",
+			releaseCmd:     "python manage.py migrate && python manage.py collectstatic --noinput",
 		},
 		{
 			name: "non-django project",
@@ -37,8 +41,64 @@ func TestDjango(t *testing.T) {
 			appName := provider.getDjangoAppName(ctx)
 			require.Equal(t, tt.appName, appName)
 
+			require.Equal(t, tt.settingsModule, provider.getDjangoSettingsModule(ctx))
+
 			startCmd := provider.getDjangoStartCommand(ctx)
 			require.Equal(t, tt.startCmd, startCmd)
+
+			err = provider.Plan(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.releaseCmd, ctx.Deploy.ReleaseCmd)
+		})
+	}
+}
+
+func TestDjangoAsgi(t *testing.T) {
+	settings := "ASGI_APPLICATION = 'config.asgi.application'\nWSGI_APPLICATION = 'config.wsgi.application'\n"
+	manage := "import os\n\nos.environ.setdefault(\"DJANGO_SETTINGS_MODULE\", \"config.settings.production\")\n"
+
+	tests := []struct {
+		name         string
+		requirements string
+		startCmd     string
+	}{
+		{
+			name:         "channels with daphne",
+			requirements: "django\nchannels\ndaphne\n",
+			startCmd:     "daphne -b 0.0.0.0 -p ${PORT:-8000} config.asgi:application",
+		},
+		{
+			name:         "uvicorn",
+			requirements: "django\nuvicorn\n",
+			startCmd:     "uvicorn config.asgi:application --host 0.0.0.0 --port ${PORT:-8000}",
+		},
+		{
+			name:         "no asgi server",
+			requirements: "django\ngunicorn\n",
+			startCmd:     "gunicorn config.wsgi:application",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContextFromFiles(t, map[string]string{
+				"requirements.txt":            tt.requirements,
+				"manage.py":                   manage,
+				"config/settings/__init__.py": "",
+				"config/settings/base.py":     settings,
+
+				// Django's default settings in the venv set STATIC_ROOT to None
+				".venv/lib/python3.12/site-packages/django/conf/global_settings.py": "STATIC_ROOT = None\n",
+			})
+			provider := PythonProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+
+			require.Equal(t, tt.startCmd, provider.getDjangoStartCommand(ctx))
+			require.Equal(t, "config.settings.production", provider.getDjangoSettingsModule(ctx))
+
+			// The project does not set STATIC_ROOT, so only migrations are run
+			require.NoError(t, provider.Plan(ctx))
+			require.Equal(t, "python manage.py migrate", ctx.Deploy.ReleaseCmd)
 		})
 	}
 }
@@ -0,0 +1,31 @@
+#!/bin/sh
+# Precompiles the bytecode of the venv and the app source, and removes files that are not
+# needed at runtime from the venv
+# Usage: optimize-venv.sh <venv> <dir>...
+set -e
+
+venv="$1"
+shift
+
+before=$(du -sk "$venv" | cut -f1)
+
+site_packages=$(find "$venv/lib" -maxdepth 2 -type d -name site-packages)
+
+# Test suites shipped with packages
+find $site_packages -mindepth 2 -type d \( -name tests -o -name test \) -prune -exec rm -rf {} +
+
+# Installed file lists, which are only used to uninstall packages
+find $site_packages -path '*.dist-info/RECORD' -type f -delete
+
+"$venv/bin/python" -m compileall -q -j 0 $site_packages
+"$venv/bin/python" -m compileall -q -j 0 -x '/\.' "$@"
+
+# Bytecode of the tools that installed the dependencies, which are not imported by the app
+for tool in pip setuptools wheel _distutils_hack pkg_resources uv poetry pdm pipenv virtualenv; do
+	for dir in $site_packages; do
+		find "$dir/$tool" -type d -name __pycache__ -prune -exec rm -rf {} + 2>/dev/null || true
+	done
+done
+
+after=$(du -sk "$venv" | cut -f1)
+echo "Optimized $venv from $((before / 1024))MB to $((after / 1024))MB"
@@ -0,0 +1,37 @@
+package python
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+)
+
+const (
+	PYTHON_OPTIMIZE_VAR       = "PYTHON_OPTIMIZE"
+	OptimizeVenvScriptPath    = "/optimize-venv.sh"
+	optimizeVenvScriptAssetID = "optimize-venv.sh"
+
+	// APP_DIR is where the app source is copied. Conda and pixi environments are not inside of it.
+	APP_DIR = "/app"
+)
+
+//go:embed optimize-venv.sh
+var optimizeVenvScript string
+
+func (p *PythonProvider) shouldOptimize(ctx *generate.GenerateContext) bool {
+	return !ctx.Dev && ctx.Env.IsConfigVariableTruthy(PYTHON_OPTIMIZE_VAR)
+}
+
+// optimizeVenv precompiles the bytecode of the venv and the app at the end of the build,
+// so it is not compiled every time the container starts, and strips the venv layer
+func (p *PythonProvider) optimizeVenv(ctx *generate.GenerateContext, build *generate.CommandStepBuilder) {
+	ctx.Logger.LogInfo("Precompiling bytecode and slimming the virtual environment")
+
+	build.Assets[optimizeVenvScriptAssetID] = optimizeVenvScript
+	build.AddCommands([]plan.Command{
+		plan.NewFileCommand(OptimizeVenvScriptPath, optimizeVenvScriptAssetID),
+		plan.NewExecCommand(fmt.Sprintf("sh %s %s %s", OptimizeVenvScriptPath, p.GetVenvPath(ctx), APP_DIR)),
+	})
+}
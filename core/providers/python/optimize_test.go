@@ -0,0 +1,76 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+	testingUtils "github.com/railwayapp/railpack/core/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPythonOptimize(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		ctx := testingUtils.CreateGenerateContext(t, "../../../examples/python-uv")
+		provider := PythonProvider{}
+		require.NoError(t, provider.Initialize(ctx))
+		require.NoError(t, provider.Plan(ctx))
+
+		build := (*ctx.GetStepByName("build")).(*generate.CommandStepBuilder)
+		require.NotContains(t, build.Assets, optimizeVenvScriptAssetID)
+		require.Empty(t, ctx.Metadata.Get("pythonOptimize"))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		ctx := testingUtils.CreateGenerateContext(t, "../../../examples/python-uv")
+		ctx.Env.SetVariable("RAILPACK_PYTHON_OPTIMIZE", "true")
+
+		provider := PythonProvider{}
+		require.NoError(t, provider.Initialize(ctx))
+		require.NoError(t, provider.Plan(ctx))
+
+		build := (*ctx.GetStepByName("build")).(*generate.CommandStepBuilder)
+		require.Equal(t, optimizeVenvScript, build.Assets[optimizeVenvScriptAssetID])
+		require.Equal(t, []plan.Command{
+			plan.NewExecCommand("/app/.venv/bin/uv sync --locked --no-dev --no-editable"),
+			plan.NewFileCommand(OptimizeVenvScriptPath, optimizeVenvScriptAssetID),
+			plan.NewExecCommand("sh /optimize-venv.sh /app/.venv /app"),
+		}, build.Commands)
+		require.Equal(t, "true", ctx.Metadata.Get("pythonOptimize"))
+	})
+
+	// The app is compiled even when the environment is outside of it
+	for _, tt := range []struct {
+		name     string
+		path     string
+		optimize string
+	}{
+		{name: "conda", path: "../../../examples/python-conda", optimize: "sh /optimize-venv.sh /opt/conda /app"},
+		{name: "pixi", path: "../../../examples/python-pixi", optimize: "sh /optimize-venv.sh /app/.pixi/envs/default /app"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContext(t, tt.path)
+			ctx.Env.SetVariable("RAILPACK_PYTHON_OPTIMIZE", "true")
+
+			provider := PythonProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+			require.NoError(t, provider.Plan(ctx))
+
+			build := (*ctx.GetStepByName("build")).(*generate.CommandStepBuilder)
+			require.Contains(t, build.Commands, plan.NewExecCommand(tt.optimize))
+		})
+	}
+
+	t.Run("skipped in dev", func(t *testing.T) {
+		ctx := testingUtils.CreateGenerateContext(t, "../../../examples/python-uv")
+		ctx.Env.SetVariable("RAILPACK_PYTHON_OPTIMIZE", "true")
+		ctx.Dev = true
+
+		provider := PythonProvider{}
+		require.NoError(t, provider.Initialize(ctx))
+		require.NoError(t, provider.Plan(ctx))
+
+		build := (*ctx.GetStepByName("build")).(*generate.CommandStepBuilder)
+		require.NotContains(t, build.Assets, optimizeVenvScriptAssetID)
+	})
+}
@@ -3,10 +3,12 @@ package python
 import (
 	"fmt"
 	"maps"
+	"path"
 	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/log"
+	"github.com/railwayapp/railpack/core/config"
 	"github.com/railwayapp/railpack/core/generate"
 	"github.com/railwayapp/railpack/core/plan"
 	"github.com/railwayapp/railpack/internal/utils"
@@ -22,6 +24,15 @@ const (
 
 // GetVenvPath returns the appropriate virtual environment path based on context
 func (p *PythonProvider) GetVenvPath(ctx *generate.GenerateContext) string {
+	// Conda and pixi environments have the same layout as a virtual environment
+	if p.hasPixi(ctx) {
+		return PIXI_ENV_PATH
+	}
+
+	if p.hasConda(ctx) {
+		return CONDA_ENV_PATH
+	}
+
 	// Always use /app/.venv for containerized environments
 	return VENV_PATH
 }
@@ -39,13 +50,33 @@ func (p *PythonProvider) GetVenvPathForInstall(ctx *generate.GenerateContext) st
 	return ".venv"
 }
 
-type PythonProvider struct{}
+type PythonProvider struct {
+	packages []*pythonPackage
+
+	// pythonPackage is the workspace member that is installed and deployed
+	pythonPackage *pythonPackage
+
+	// appObject is the ASGI or WSGI application found in the source files
+	appObject *pythonAppObject
+}
 
 func (p *PythonProvider) Name() string {
 	return "python"
 }
 
 func (p *PythonProvider) Initialize(ctx *generate.GenerateContext) error {
+	if packageName := p.getPackageName(ctx); packageName != "" {
+		packages, err := findPythonPackages(ctx)
+		if err != nil {
+			return err
+		}
+
+		p.packages = packages
+		p.pythonPackage = findPythonPackage(packages, packageName)
+	}
+
+	p.appObject = detectAppObject(ctx)
+
 	return nil
 }
 
@@ -53,33 +84,63 @@ func (p *PythonProvider) Detect(ctx *generate.GenerateContext) (bool, error) {
 	hasPython := ctx.App.HasMatch("main.py") ||
 		p.hasRequirements(ctx) ||
 		p.hasPyproject(ctx) ||
-		p.hasPipfile(ctx)
+		p.hasPipfile(ctx) ||
+		p.hasConda(ctx) ||
+		p.hasPixi(ctx)
 
 	return hasPython, nil
 }
 
 func (p *PythonProvider) Plan(ctx *generate.GenerateContext) error {
+	if packageName := p.getPackageName(ctx); packageName != "" && p.pythonPackage == nil {
+		return fmt.Errorf("python package `%s` not found. Available packages: %s", packageName, strings.Join(p.pythonPackagePaths(), ", "))
+	}
+
+	if p.pythonPackage != nil {
+		ctx.Logger.LogInfo("Installing workspace package %s", p.pythonPackage.Path)
+	}
+
 	p.InstallMisePackages(ctx, ctx.GetMiseStepBuilder())
 
+	// Conda packages include the native libraries they need
+	defaultSystemPackages := pythonSystemPackages
+	if p.usesCondaEnvironment(ctx) {
+		defaultSystemPackages = nil
+	}
+
+	systemPackages := ctx.GetSystemPackages("python", defaultSystemPackages, func(dep string) bool {
+		return p.usesDep(ctx, dep)
+	})
+
 	install := ctx.NewCommandStep("install")
-	install.AddInput(plan.NewStepLayer(p.GetBuilderDeps(ctx).Name()))
+	install.AddInput(plan.NewStepLayer(p.GetBuilderDeps(ctx, systemPackages).Name()))
 
 	install.Secrets = []string{}
-	install.UseSecretsWithPrefixes([]string{"PYTHON", "PIP", "PIPX", "UV", "PDM", "POETRY"})
+	install.UseSecretsWithPrefixes([]string{"PYTHON", "PIP", "PIPX", "UV", "PDM", "POETRY", "CONDA", "MAMBA", "PIXI"})
 
 	build := ctx.NewCommandStep("build")
 	installOutputs := []string{}
 
-	if p.hasRequirements(ctx) {
+	if p.hasPixi(ctx) {
+		installOutputs = p.InstallPixi(ctx, install)
+	} else if p.hasConda(ctx) {
+		installOutputs = p.InstallConda(ctx, install)
+	} else if p.hasRequirements(ctx) {
 		installOutputs = p.InstallPip(ctx, install)
 	} else if p.hasPyproject(ctx) && p.hasUv(ctx) {
 		installOutputs = p.InstallUv(ctx, install)
 		build.AddCommands([]plan.Command{
 			// the project is not installed during the install phase, because it requires the project source
-			plan.NewExecCommand(fmt.Sprintf("%s/bin/uv sync --locked --no-dev --no-editable", p.GetVenvPath(ctx))),
+			plan.NewExecCommand(fmt.Sprintf("%s/bin/uv sync --locked --no-dev --no-editable%s", p.GetVenvPath(ctx), p.uvPackageArgs())),
 		})
 	} else if p.hasPyproject(ctx) && p.hasPoetry(ctx) {
 		installOutputs = p.InstallPoetry(ctx, install)
+		if p.pythonPackage != nil {
+			// the workspace member is installed with its scripts once the source is available
+			build.AddCommands([]plan.Command{
+				plan.NewExecCommand(fmt.Sprintf("%s/bin/poetry install --no-interaction --no-ansi --only main%s", p.GetVenvPath(ctx), p.poetryPackageArgs())),
+			})
+		}
 	} else if p.hasPyproject(ctx) && p.hasPdm(ctx) {
 		installOutputs = p.InstallPDM(ctx, install)
 	} else if p.hasPipfile(ctx) {
@@ -91,7 +152,23 @@ func (p *PythonProvider) Plan(ctx *generate.GenerateContext) error {
 	build.AddInput(plan.NewStepLayer(install.Name()))
 	build.AddInput(plan.NewLocalLayer())
 
+	if p.shouldOptimize(ctx) {
+		p.optimizeVenv(ctx, build)
+	}
+
 	ctx.Deploy.StartCmd = p.GetStartCommand(ctx)
+	if !ctx.Dev {
+		p.warnJupyterToken(ctx, ctx.Deploy.StartCmd)
+	}
+
+	// Migrations run once per deployment, not every time a replica starts
+	if p.isDjango(ctx) && !ctx.Dev {
+		ctx.Deploy.ReleaseCmd = p.getDjangoReleaseCommand(ctx)
+	}
+
+	if p.hasConda(ctx) && !p.hasPixi(ctx) {
+		ctx.Deploy.Variables["CONDA_PREFIX"] = CONDA_ENV_PATH
+	}
 
 	// Use different environment variables for dev vs production
 	if ctx.Dev {
@@ -112,7 +189,7 @@ func (p *PythonProvider) Plan(ctx *generate.GenerateContext) error {
 		if devCmd := p.GetDevStartCommand(ctx); devCmd != "" {
 			ctx.Deploy.StartCmd = devCmd
 			ctx.Deploy.StartCmdHost = p.GetDevStartCommandHost(ctx)
-			ctx.Deploy.RequiredPort = p.getDevPort(ctx) // Get framework-specific port
+			ctx.SetRequiredPort(p.getDevPort(ctx, devCmd))
 		}
 	}
 
@@ -120,14 +197,20 @@ func (p *PythonProvider) Plan(ctx *generate.GenerateContext) error {
 		Include: installOutputs,
 	})
 
-	p.AddRuntimeDeps(ctx)
+	p.AddRuntimeDeps(ctx, systemPackages)
+
+	// The environment is in the install artifacts if it is in the app directory
+	sourceExclude := []string{}
+	if envDir, ok := strings.CutPrefix(p.GetVenvPath(ctx), "/app/"); ok {
+		sourceExclude = append(sourceExclude, envDir)
+	}
 
 	ctx.Deploy.AddInputs([]plan.Layer{
 		ctx.GetMiseStepBuilder().GetLayer(),
 		installArtifacts,
 		plan.NewStepLayer(build.Name(), plan.Filter{
 			Include: []string{"."},
-			Exclude: []string{strings.TrimPrefix(p.GetVenvPath(ctx), "/app/")},
+			Exclude: sourceExclude,
 		}),
 	})
 
@@ -135,6 +218,12 @@ func (p *PythonProvider) Plan(ctx *generate.GenerateContext) error {
 }
 
 func (p *PythonProvider) GetStartCommand(ctx *generate.GenerateContext) string {
+	if p.pythonPackage != nil {
+		if startCommand := p.getPackageStartCommand(ctx); startCommand != "" {
+			return startCommand
+		}
+	}
+
 	startCommand := ""
 	hasPoetry := p.hasPoetry(ctx)
 
@@ -145,23 +234,31 @@ func (p *PythonProvider) GetStartCommand(ctx *generate.GenerateContext) string {
 	mainPythonFile := p.getMainPythonFile(ctx)
 	hasMainPythonFile := mainPythonFile != ""
 
-	if p.isFasthtml(ctx) && hasMainPythonFile && p.usesDep(ctx, "uvicorn") {
-		if hasPoetry {
-			startCommand = "poetry run uvicorn main:app --host 0.0.0.0 --port ${PORT:-8000}"
-		} else {
-			startCommand = "uvicorn main:app --host 0.0.0.0 --port ${PORT:-8000}"
+	if app := p.appObject; startCommand == "" && app != nil && app.Framework != "django" {
+		if app.Interface == AppInterfaceASGI && p.usesDep(ctx, "uvicorn") {
+			startCommand = fmt.Sprintf("uvicorn %s --host 0.0.0.0 --port ${PORT:-8000}", app.UvicornArgs())
 		}
-	}
 
-	if p.isFlask(ctx) && hasMainPythonFile && p.usesDep(ctx, "gunicorn") {
-		if hasPoetry {
-			startCommand = "poetry run gunicorn --bind 0.0.0.0:${PORT:-8000} main:app"
-		} else {
-			startCommand = "gunicorn --bind 0.0.0.0:${PORT:-8000} main:app"
+		if app.Interface == AppInterfaceWSGI && p.usesDep(ctx, "gunicorn") {
+			startCommand = fmt.Sprintf("gunicorn --bind 0.0.0.0:${PORT:-8000} %s", app.GunicornArgs())
 		}
+
+		if startCommand != "" && hasPoetry {
+			startCommand = "poetry run " + startCommand
+		}
+	}
+
+	scriptCommand, preferredScript := p.getScriptStartCommand(ctx)
+	if startCommand == "" && preferredScript {
+		startCommand = scriptCommand
 	}
 
-	if startCommand == "" && hasMainPythonFile {
+	if startCommand == "" {
+		startCommand = p.getDataAppStartCommand(ctx)
+	}
+
+	// Notebooks are served by Jupyter or Voila instead of being run with python
+	if startCommand == "" && hasMainPythonFile && !strings.HasSuffix(mainPythonFile, ".ipynb") {
 		if hasPoetry {
 			startCommand = fmt.Sprintf("poetry run python %s", mainPythonFile)
 		} else {
@@ -170,6 +267,14 @@ func (p *PythonProvider) GetStartCommand(ctx *generate.GenerateContext) string {
 		}
 	}
 
+	if startCommand == "" {
+		startCommand = scriptCommand
+	}
+
+	if startCommand == "" {
+		startCommand = p.getNotebookStartCommand(ctx)
+	}
+
 	return startCommand
 }
 
@@ -189,14 +294,15 @@ func (p *PythonProvider) GetDevStartCommand(ctx *generate.GenerateContext) strin
 
 	mainPythonFile := p.getMainPythonFile(ctx)
 	hasMainPythonFile := mainPythonFile != ""
+	app := p.appObject
 
-	// FastAPI (uvicorn) with reload if available
-	if p.isFastAPI(ctx) && hasMainPythonFile {
+	// ASGI apps (FastAPI, Starlette, FastHTML) with uvicorn and reload
+	if app != nil && app.Interface == AppInterfaceASGI && app.Framework != "django" && (p.isFastAPI(ctx) || p.usesDep(ctx, "uvicorn")) {
 		if hasPoetry {
-			return "poetry run uvicorn main:app --reload --host 0.0.0.0 --port 8000"
+			return fmt.Sprintf("poetry run uvicorn %s --reload --host 0.0.0.0 --port 8000", app.UvicornArgs())
 		}
 		venvPath := p.GetVenvPath(ctx)
-		return fmt.Sprintf("%s/bin/uvicorn main:app --reload --host 0.0.0.0 --port 8000", venvPath)
+		return fmt.Sprintf("%s/bin/uvicorn %s --reload --host 0.0.0.0 --port 8000", venvPath, app.UvicornArgs())
 	}
 
 	// Streamlit apps
@@ -228,15 +334,20 @@ func (p *PythonProvider) GetDevStartCommand(ctx *generate.GenerateContext) strin
 
 	// Flask: prefer flask dev server if flask is present
 	if p.isFlask(ctx) {
+		flaskApp := mainPythonFile
+		if app != nil && app.Framework == "flask" {
+			flaskApp = app.quote(app.FlaskApp())
+		}
+
 		if hasPoetry {
-			if mainPythonFile != "" {
-				return fmt.Sprintf("poetry run flask --app %s run --host 0.0.0.0 --port 5000", mainPythonFile)
+			if flaskApp != "" {
+				return fmt.Sprintf("poetry run flask --app %s run --host 0.0.0.0 --port 5000", flaskApp)
 			}
 			return "poetry run flask run --host 0.0.0.0 --port 5000"
 		}
 		venvPath := p.GetVenvPath(ctx)
-		if mainPythonFile != "" {
-			return fmt.Sprintf("%s/bin/flask --app %s run --host 0.0.0.0 --port 5000", venvPath, mainPythonFile)
+		if flaskApp != "" {
+			return fmt.Sprintf("%s/bin/flask --app %s run --host 0.0.0.0 --port 5000", venvPath, flaskApp)
 		}
 		return fmt.Sprintf("%s/bin/flask run --host 0.0.0.0 --port 5000", venvPath)
 	}
@@ -268,14 +379,15 @@ func (p *PythonProvider) GetDevStartCommandHost(ctx *generate.GenerateContext) s
 
 	mainPythonFile := p.getMainPythonFile(ctx)
 	hasMainPythonFile := mainPythonFile != ""
+	app := p.appObject
 
-	// FastAPI (uvicorn) with reload if available
-	if p.isFastAPI(ctx) && hasMainPythonFile {
+	// ASGI apps (FastAPI, Starlette, FastHTML) with uvicorn and reload
+	if app != nil && app.Interface == AppInterfaceASGI && app.Framework != "django" && (p.isFastAPI(ctx) || p.usesDep(ctx, "uvicorn")) {
 		if hasPoetry {
-			return "poetry run uvicorn main:app --reload --host 0.0.0.0 --port 8000"
+			return fmt.Sprintf("poetry run uvicorn %s --reload --host 0.0.0.0 --port 8000", app.UvicornArgs())
 		}
 		venvPath := p.GetVenvPathForHost(ctx)
-		return fmt.Sprintf("%s/bin/uvicorn main:app --reload --host 0.0.0.0 --port 8000", venvPath)
+		return fmt.Sprintf("%s/bin/uvicorn %s --reload --host 0.0.0.0 --port 8000", venvPath, app.UvicornArgs())
 	}
 
 	// Streamlit apps
@@ -307,15 +419,20 @@ func (p *PythonProvider) GetDevStartCommandHost(ctx *generate.GenerateContext) s
 
 	// Flask: prefer flask dev server if flask is present
 	if p.isFlask(ctx) {
+		flaskApp := mainPythonFile
+		if app != nil && app.Framework == "flask" {
+			flaskApp = app.quote(app.FlaskApp())
+		}
+
 		if hasPoetry {
-			if mainPythonFile != "" {
-				return fmt.Sprintf("poetry run flask --app %s run --host 0.0.0.0 --port 5000", mainPythonFile)
+			if flaskApp != "" {
+				return fmt.Sprintf("poetry run flask --app %s run --host 0.0.0.0 --port 5000", flaskApp)
 			}
 			return "poetry run flask run --host 0.0.0.0 --port 5000"
 		}
 		venvPath := p.GetVenvPathForHost(ctx)
-		if mainPythonFile != "" {
-			return fmt.Sprintf("%s/bin/flask --app %s run --host 0.0.0.0 --port 5000", venvPath, mainPythonFile)
+		if flaskApp != "" {
+			return fmt.Sprintf("%s/bin/flask --app %s run --host 0.0.0.0 --port 5000", venvPath, flaskApp)
 		}
 		return fmt.Sprintf("%s/bin/flask run --host 0.0.0.0 --port 5000", venvPath)
 	}
@@ -331,8 +448,19 @@ func (p *PythonProvider) GetDevStartCommandHost(ctx *generate.GenerateContext) s
 	return ""
 }
 
-// getDevPort returns the appropriate port for development mode based on framework
-func (p *PythonProvider) getDevPort(ctx *generate.GenerateContext) string {
+// getDevPort returns the port for development mode and where it was found.
+// The port is read from the arguments of the dev command (e.g. runserver 0.0.0.0:8000 or uvicorn --port 8000),
+// falling back to the framework default.
+func (p *PythonProvider) getDevPort(ctx *generate.GenerateContext, devCmd string) (string, string) {
+	if port := generate.PortFromCommand(devCmd); port != "" {
+		return port, "start command"
+	}
+
+	return p.getDefaultDevPort(ctx), generate.PortSourceDefault
+}
+
+// getDefaultDevPort returns the default port for development mode based on framework
+func (p *PythonProvider) getDefaultDevPort(ctx *generate.GenerateContext) string {
 	if p.isDjango(ctx) {
 		return "8000" // Django runserver default
 	}
@@ -411,6 +539,12 @@ func (p *PythonProvider) getMainPythonFile(ctx *generate.GenerateContext) string
 			return file
 		}
 	}
+
+	// Fall back to the module that defines the application (e.g. in a src/ layout)
+	if app := p.appObject; app != nil && app.Framework != "django" && !strings.HasSuffix(app.File, "__init__.py") {
+		return app.File
+	}
+
 	return ""
 }
 
@@ -428,7 +562,8 @@ func (p *PythonProvider) StartCommandHelp() string {
 		"• Data science scripts with python\n\n" +
 		"**Other Applications:**\n" +
 		"• Web scraping tools with python\n" +
-		"• General Python scripts with python\n\n" +
+		"• General Python scripts with python\n" +
+		"• Scripts from [project.scripts] or [tool.poetry.scripts] in pyproject.toml, preferring one named start, serve, or web\n\n" +
 		"In development mode, your application will use development servers with hot reloading and appropriate ports for each framework."
 }
 
@@ -459,7 +594,7 @@ func (p *PythonProvider) InstallUv(ctx *generate.GenerateContext, install *gener
 		// if we exclude workspace packages, uv.lock will fail the frozen test and the user will get an error
 		// to avoid this, we (a) detect if workspace packages are required (b) if they aren't, we don't include project
 		// source in order to optimize layer caching (c) install project in the build phase.
-		plan.NewExecCommand(fmt.Sprintf("%s/bin/uv sync --locked --no-dev --no-install-project", p.GetVenvPath(ctx))), // Use absolute path
+		plan.NewExecCommand(fmt.Sprintf("%s/bin/uv sync --locked --no-dev --no-install-project%s", p.GetVenvPath(ctx), p.uvPackageArgs())), // Use absolute path
 	})
 
 	return []string{venvPath}
@@ -553,7 +688,7 @@ func (p *PythonProvider) InstallPoetry(ctx *generate.GenerateContext, install *g
 		// Install poetry in the virtual environment
 		plan.NewExecCommand(fmt.Sprintf("%s/bin/pip install poetry", p.GetVenvPath(ctx))), // Use absolute path
 		// Install dependencies with poetry
-		plan.NewExecCommand(fmt.Sprintf("%s/bin/poetry install --no-interaction --no-ansi --only main --no-root", p.GetVenvPath(ctx))), // Use absolute path
+		plan.NewExecCommand(fmt.Sprintf("%s/bin/poetry install --no-interaction --no-ansi --only main --no-root%s", p.GetVenvPath(ctx), p.poetryPackageArgs())), // Use absolute path
 	})
 
 	return []string{venvPath}
@@ -583,12 +718,11 @@ func (p *PythonProvider) InstallPip(ctx *generate.GenerateContext, install *gene
 	return []string{venvPath}
 }
 
-func (p *PythonProvider) AddRuntimeDeps(ctx *generate.GenerateContext) {
-	for dep, requiredPkgs := range pythonRuntimeDepRequirements {
-		if p.usesDep(ctx, dep) {
-			ctx.Logger.LogInfo("Installing runtime apt packages for %s", dep)
-			ctx.Deploy.AddAptPackages(requiredPkgs)
-		}
+func (p *PythonProvider) AddRuntimeDeps(ctx *generate.GenerateContext, systemPackages config.SystemPackages) {
+	ctx.Deploy.AddAptPackages(systemPackages.Runtime)
+
+	if p.usesCondaEnvironment(ctx) {
+		return
 	}
 
 	if p.usesPostgres(ctx) {
@@ -600,17 +734,16 @@ func (p *PythonProvider) AddRuntimeDeps(ctx *generate.GenerateContext) {
 	}
 }
 
-func (p *PythonProvider) GetBuilderDeps(ctx *generate.GenerateContext) *generate.MiseStepBuilder {
+func (p *PythonProvider) GetBuilderDeps(ctx *generate.GenerateContext, systemPackages config.SystemPackages) *generate.MiseStepBuilder {
 	miseStep := ctx.GetMiseStepBuilder()
-	miseStep.SupportingAptPackages = append(miseStep.SupportingAptPackages, "python3-dev", "gcc", "g++", "libc6-dev", "build-essential")
+	miseStep.SupportingAptPackages = append(miseStep.SupportingAptPackages, systemPackages.Build...)
 
-	for dep, requiredPkgs := range pythonBuildDepRequirements {
-		if p.usesDep(ctx, dep) {
-			ctx.Logger.LogInfo("Installing build apt packages for %s", dep)
-			miseStep.SupportingAptPackages = append(miseStep.SupportingAptPackages, requiredPkgs...)
-		}
+	if p.usesCondaEnvironment(ctx) {
+		return miseStep
 	}
 
+	miseStep.SupportingAptPackages = append(miseStep.SupportingAptPackages, "python3-dev", "gcc", "g++", "libc6-dev", "build-essential")
+
 	if p.usesPostgres(ctx) {
 		miseStep.SupportingAptPackages = append(miseStep.SupportingAptPackages, "libpq-dev")
 	}
@@ -623,6 +756,17 @@ func (p *PythonProvider) GetBuilderDeps(ctx *generate.GenerateContext) *generate
 }
 
 func (p *PythonProvider) InstallMisePackages(ctx *generate.GenerateContext, miseStep *generate.MiseStepBuilder) {
+	// Python is installed into conda and pixi environments with the other packages
+	if p.hasPixi(ctx) {
+		miseStep.Default("pixi", "latest")
+		return
+	}
+
+	if p.hasConda(ctx) {
+		miseStep.Default("micromamba", "latest")
+		return
+	}
+
 	python := miseStep.Default("python", DEFAULT_PYTHON_VERSION)
 
 	if envVersion, varName := ctx.Env.GetConfigVariable("PYTHON_VERSION"); envVersion != "" {
@@ -690,7 +834,9 @@ func (p *PythonProvider) GetPythonDevEnvVars(ctx *generate.GenerateContext) map[
 		if p.isDjango(ctx) {
 			// Django uses databases by default
 			envVars["DATABASE_URL"] = "sqlite:///db.sqlite3"
-			envVars["DJANGO_SETTINGS_MODULE"] = "mysite.settings"
+			if settingsModule := p.getDjangoSettingsModule(ctx); settingsModule != "" {
+				envVars["DJANGO_SETTINGS_MODULE"] = settingsModule
+			}
 			envVars["DJANGO_COLLECTSTATIC"] = "False"
 			envVars["DJANGO_DEBUG"] = "True"
 		} else if p.isFlask(ctx) {
@@ -699,6 +845,9 @@ func (p *PythonProvider) GetPythonDevEnvVars(ctx *generate.GenerateContext) map[
 				envVars["DATABASE_URL"] = "sqlite:///db.sqlite3"
 			}
 			envVars["FLASK_APP"] = "main.py"
+			if app := p.appObject; app != nil && app.Framework == "flask" {
+				envVars["FLASK_APP"] = app.FlaskApp()
+			}
 			envVars["FLASK_ENV"] = "development"
 			envVars["FLASK_DEBUG"] = "True"
 		} else if p.isFastAPI(ctx) {
@@ -739,6 +888,8 @@ func (p *PythonProvider) GetPythonProdEnvVars(ctx *generate.GenerateContext) map
 	envVars["IN_CONTAINER"] = "1"
 	envVars["PYTHONPATH"] = "/app"
 
+	maps.Copy(envVars, p.getDataAppEnvVars(ctx))
+
 	// Add MPLBACKEND for data science apps in production
 	if p.isDataScience(ctx) {
 		envVars["MPLBACKEND"] = "Agg"
@@ -774,6 +925,10 @@ func (p *PythonProvider) copyInstallFiles(ctx *generate.GenerateContext, install
 // inspect python dependency files and determine if local packages are referenced, and therefore all files are required
 // for installation.
 func (p *PythonProvider) installNeedsAllFiles(ctx *generate.GenerateContext) bool {
+	if p.pythonPackage != nil {
+		return true
+	}
+
 	if requirementsContent, err := ctx.App.ReadFile("requirements.txt"); err == nil {
 		return strings.Contains(requirementsContent, "file://")
 	}
@@ -822,7 +977,11 @@ func (p *PythonProvider) addMetadata(ctx *generate.GenerateContext) {
 
 	pkgManager := "pip"
 
-	if hasPoetry {
+	if p.hasPixi(ctx) {
+		pkgManager = "pixi"
+	} else if p.hasConda(ctx) {
+		pkgManager = "conda"
+	} else if hasPoetry {
 		pkgManager = "poetry"
 	} else if hasPdm {
 		pkgManager = "pdm"
@@ -831,11 +990,26 @@ func (p *PythonProvider) addMetadata(ctx *generate.GenerateContext) {
 	}
 
 	ctx.Metadata.Set("pythonPackageManager", pkgManager)
+	if p.pythonPackage != nil {
+		ctx.Metadata.Set("pythonPackage", p.pythonPackage.Path)
+	}
 	ctx.Metadata.Set("pythonRuntime", p.getRuntime(ctx))
+	ctx.Metadata.SetBool("pythonOptimize", p.shouldOptimize(ctx))
+
+	if app := p.appObject; app != nil {
+		ctx.Metadata.Set("pythonAppObject", app.Target())
+		ctx.Metadata.Set("pythonAppInterface", app.Interface)
+		ctx.Metadata.Set("pythonAppFile", app.File)
+	}
 }
 
 func (p *PythonProvider) usesDep(ctx *generate.GenerateContext, dep string) bool {
-	for _, file := range []string{"requirements.txt", "pyproject.toml", "Pipfile"} {
+	files := append([]string{"requirements.txt", "pyproject.toml", "Pipfile", PIXI_MANIFEST}, condaEnvironmentFiles...)
+	if p.pythonPackage != nil {
+		files = append(files, path.Join(p.pythonPackage.Path, "pyproject.toml"))
+	}
+
+	for _, file := range files {
 		if contents, err := ctx.App.ReadFile(file); err == nil {
 			// TODO: Do something better than string comparison
 			if strings.Contains(strings.ToLower(contents), strings.ToLower(dep)) {
@@ -846,6 +1020,69 @@ func (p *PythonProvider) usesDep(ctx *generate.GenerateContext, dep string) bool
 	return false
 }
 
+var requirementNameRegex = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+// usesMainDep checks if a dependency is installed in production. Unlike usesDep, dev dependencies
+// (dependency groups, optional dependencies, Poetry groups, and Pipfile dev-packages) are not matched.
+func (p *PythonProvider) usesMainDep(ctx *generate.GenerateContext, dep string) bool {
+	for _, name := range p.getMainDeps(ctx) {
+		if normalizePackageName(name) == normalizePackageName(dep) {
+			return true
+		}
+	}
+
+	// Conda and pixi environments do not separate dev dependencies in a way we can check
+	for _, file := range append([]string{PIXI_MANIFEST}, condaEnvironmentFiles...) {
+		if contents, err := ctx.App.ReadFile(file); err == nil && strings.Contains(strings.ToLower(contents), strings.ToLower(dep)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getMainDeps returns the names of the dependencies in requirements.txt, the main dependencies of pyproject.toml, and the packages of the Pipfile
+func (p *PythonProvider) getMainDeps(ctx *generate.GenerateContext) []string {
+	requirements := []string{}
+	if contents, err := ctx.App.ReadFile("requirements.txt"); err == nil {
+		requirements = append(requirements, strings.Split(contents, "\n")...)
+	}
+
+	pyprojects := []string{"pyproject.toml"}
+	if p.pythonPackage != nil {
+		pyprojects = append(pyprojects, path.Join(p.pythonPackage.Path, "pyproject.toml"))
+	}
+
+	names := []string{}
+	for _, file := range pyprojects {
+		var pyproject pyprojectToml
+		if err := ctx.App.ReadTOML(file, &pyproject); err != nil {
+			continue
+		}
+		requirements = append(requirements, pyproject.Project.Dependencies...)
+		for name := range pyproject.Tool.Poetry.Dependencies {
+			names = append(names, name)
+		}
+	}
+
+	var pipfile struct {
+		Packages map[string]any `toml:"packages"`
+	}
+	if err := ctx.App.ReadTOML("Pipfile", &pipfile); err == nil {
+		for name := range pipfile.Packages {
+			names = append(names, name)
+		}
+	}
+
+	for _, requirement := range requirements {
+		if match := requirementNameRegex.FindStringSubmatch(requirement); match != nil {
+			names = append(names, match[1])
+		}
+	}
+
+	return names
+}
+
 var pipfileFullVersionRegex = regexp.MustCompile(`python_full_version\s*=\s*['"]([0-9.]*)"?`)
 var pipfileShortVersionRegex = regexp.MustCompile(`python_version\s*=\s*['"]([0-9.]*)"?`)
 
@@ -879,6 +1116,9 @@ func (p *PythonProvider) hasPipfile(ctx *generate.GenerateContext) bool {
 }
 
 func (p *PythonProvider) hasPoetry(ctx *generate.GenerateContext) bool {
+	if p.pythonPackage != nil && ctx.App.HasFile(path.Join(p.pythonPackage.Path, "poetry.lock")) {
+		return true
+	}
 	return ctx.App.HasMatch("poetry.lock")
 }
 
@@ -890,6 +1130,22 @@ func (p *PythonProvider) hasUv(ctx *generate.GenerateContext) bool {
 	return ctx.App.HasMatch("uv.lock")
 }
 
+// uvPackageArgs limits uv sync to the targeted workspace member and its dependencies
+func (p *PythonProvider) uvPackageArgs() string {
+	if p.pythonPackage == nil {
+		return ""
+	}
+	return " --package " + p.pythonPackage.Name()
+}
+
+// poetryPackageArgs runs poetry in the directory of the targeted project
+func (p *PythonProvider) poetryPackageArgs() string {
+	if p.pythonPackage == nil {
+		return ""
+	}
+	return " --directory " + p.pythonPackage.Path
+}
+
 func (p *PythonProvider) isFasthtml(ctx *generate.GenerateContext) bool {
 	return p.usesDep(ctx, "python-fasthtml")
 }
@@ -914,6 +1170,10 @@ func (p *PythonProvider) isJupyter(ctx *generate.GenerateContext) bool {
 	return p.usesDep(ctx, "jupyter") || p.usesDep(ctx, "notebook") || p.usesDep(ctx, "jupyterlab")
 }
 
+func (p *PythonProvider) isVoila(ctx *generate.GenerateContext) bool {
+	return p.usesDep(ctx, "voila")
+}
+
 func (p *PythonProvider) isDataScience(ctx *generate.GenerateContext) bool {
 	return p.usesDep(ctx, "pandas") || p.usesDep(ctx, "numpy") || p.usesDep(ctx, "matplotlib") ||
 		p.usesDep(ctx, "seaborn") || p.usesDep(ctx, "scikit-learn") || p.usesDep(ctx, "tensorflow") ||
@@ -955,47 +1215,27 @@ func (p *PythonProvider) getRuntime(ctx *generate.GenerateContext) string {
 	return "python"
 }
 
-// Mapping of python dependencies to required apt packages
-
-var pythonBuildDepRequirements = map[string][]string{
-	"pycairo":      {"libcairo2-dev"},
-	"pillow":       {"libjpeg-dev", "zlib1g-dev", "libpng-dev"},
-	"opencv":       {"libopencv-dev", "libglib2.0-0", "libsm6", "libxext6", "libxrender-dev", "libgomp1"},
-	"numpy":        {"libopenblas-dev", "liblapack-dev", "gfortran"},
-	"scipy":        {"libopenblas-dev", "liblapack-dev", "gfortran"},
-	"pandas":       {"libopenblas-dev", "liblapack-dev", "gfortran"},
-	"matplotlib":   {"libfreetype6-dev", "libpng-dev"},
-	"scikit-learn": {"libopenblas-dev", "liblapack-dev", "gfortran"},
-	"tensorflow":   {"libcudnn8", "libcudnn8-dev", "libcublas11", "libcublas-dev"},
-	"pytorch":      {"libcudnn8", "libcudnn8-dev", "libcublas11", "libcublas-dev"},
-	"lxml":         {"libxml2-dev", "libxslt1-dev"},
-	"cryptography": {"libssl-dev", "libffi-dev"},
-	"psycopg2":     {"libpq-dev"},
-	"mysqlclient":  {"default-libmysqlclient-dev"},
-	"redis":        {"redis-server"},
-	"selenium":     {"chromium-browser", "chromium-chromedriver"},
-	"playwright":   {"chromium-browser", "chromium-chromedriver"},
-}
-
-var pythonRuntimeDepRequirements = map[string][]string{
-	"pycairo":      {"libcairo2"},
-	"pdf2image":    {"poppler-utils"},
-	"pydub":        {"ffmpeg"},
-	"pymovie":      {"ffmpeg", "qt5-qmake", "qtbase5-dev", "qtbase5-dev-tools", "qttools5-dev-tools", "libqt5core5a", "python3-pyqt5"},
-	"pillow":       {"libjpeg62-turbo", "zlib1g", "libpng16-16"},
-	"opencv":       {"libopencv-core4.5", "libopencv-imgproc4.5", "libopencv-imgcodecs4.5", "libglib2.0-0", "libsm6", "libxext6", "libxrender1", "libgomp1"},
-	"numpy":        {"libopenblas0", "liblapack3", "gfortran"},
-	"scipy":        {"libopenblas0", "liblapack3", "gfortran"},
-	"pandas":       {"libopenblas0", "liblapack3", "gfortran"},
-	"matplotlib":   {"libfreetype6", "libpng16-16"},
-	"scikit-learn": {"libopenblas0", "liblapack3", "gfortran"},
-	"tensorflow":   {"libcudnn8", "libcublas11"},
-	"pytorch":      {"libcudnn8", "libcublas11"},
-	"lxml":         {"libxml2", "libxslt1.1"},
-	"cryptography": {"libssl1.1", "libffi7"},
-	"psycopg2":     {"libpq5"},
-	"mysqlclient":  {"default-mysql-client"},
-	"redis":        {"redis-server"},
-	"selenium":     {"chromium-browser", "chromium-chromedriver"},
-	"playwright":   {"chromium-browser", "chromium-chromedriver"},
+// Mapping of python dependencies to the apt packages needed to build and run them
+
+var pythonSystemPackages = map[string]config.SystemPackages{
+	"pycairo":      {Build: []string{"libcairo2-dev"}, Runtime: []string{"libcairo2"}},
+	"pillow":       {Build: []string{"libjpeg-dev", "zlib1g-dev", "libpng-dev"}, Runtime: []string{"libjpeg62-turbo", "zlib1g", "libpng16-16"}},
+	"opencv":       {Build: []string{"libopencv-dev", "libglib2.0-0", "libsm6", "libxext6", "libxrender-dev", "libgomp1"}, Runtime: []string{"libopencv-core4.5", "libopencv-imgproc4.5", "libopencv-imgcodecs4.5", "libglib2.0-0", "libsm6", "libxext6", "libxrender1", "libgomp1"}},
+	"numpy":        {Build: []string{"libopenblas-dev", "liblapack-dev", "gfortran"}, Runtime: []string{"libopenblas0", "liblapack3", "gfortran"}},
+	"scipy":        {Build: []string{"libopenblas-dev", "liblapack-dev", "gfortran"}, Runtime: []string{"libopenblas0", "liblapack3", "gfortran"}},
+	"pandas":       {Build: []string{"libopenblas-dev", "liblapack-dev", "gfortran"}, Runtime: []string{"libopenblas0", "liblapack3", "gfortran"}},
+	"matplotlib":   {Build: []string{"libfreetype6-dev", "libpng-dev"}, Runtime: []string{"libfreetype6", "libpng16-16"}},
+	"scikit-learn": {Build: []string{"libopenblas-dev", "liblapack-dev", "gfortran"}, Runtime: []string{"libopenblas0", "liblapack3", "gfortran"}},
+	"tensorflow":   {Build: []string{"libcudnn8", "libcudnn8-dev", "libcublas11", "libcublas-dev"}, Runtime: []string{"libcudnn8", "libcublas11"}},
+	"pytorch":      {Build: []string{"libcudnn8", "libcudnn8-dev", "libcublas11", "libcublas-dev"}, Runtime: []string{"libcudnn8", "libcublas11"}},
+	"lxml":         {Build: []string{"libxml2-dev", "libxslt1-dev"}, Runtime: []string{"libxml2", "libxslt1.1"}},
+	"cryptography": {Build: []string{"libssl-dev", "libffi-dev"}, Runtime: []string{"libssl1.1", "libffi7"}},
+	"psycopg2":     {Build: []string{"libpq-dev"}, Runtime: []string{"libpq5"}},
+	"mysqlclient":  {Build: []string{"default-libmysqlclient-dev"}, Runtime: []string{"default-mysql-client"}},
+	"redis":        {Build: []string{"redis-server"}, Runtime: []string{"redis-server"}},
+	"selenium":     {Build: []string{"chromium-browser", "chromium-chromedriver"}, Runtime: []string{"chromium-browser", "chromium-chromedriver"}},
+	"playwright":   {Build: []string{"chromium-browser", "chromium-chromedriver"}, Runtime: []string{"chromium-browser", "chromium-chromedriver"}},
+	"pdf2image":    {Runtime: []string{"poppler-utils"}},
+	"pydub":        {Runtime: []string{"ffmpeg"}},
+	"pymovie":      {Runtime: []string{"ffmpeg", "qt5-qmake", "qtbase5-dev", "qtbase5-dev-tools", "qttools5-dev-tools", "libqt5core5a", "python3-pyqt5"}},
 }
@@ -26,6 +26,7 @@ func TestPython_Django_Dev_UsesRunserver(t *testing.T) {
 	require.Contains(t, ctx.Deploy.StartCmd, "0.0.0.0:8000")
     require.NotEqual(t, ctx.Deploy.StartCmd, ctx.Deploy.StartCmdHost) // Dev mode should have different host command
     require.Equal(t, "8000", ctx.Deploy.RequiredPort)
+    require.Equal(t, "start command", ctx.Metadata.Get("requiredPortSource"))
 }
 
 func TestPython_Flask_Dev_UsesFlaskRun(t *testing.T) {
@@ -64,12 +65,12 @@ func TestPython_GetDevStartCommand(t *testing.T) {
         {
             name:     "Flask project",
             path:     "../../../examples/python-flask",
-            expected: "/app/.venv/bin/flask --app main.py run --host 0.0.0.0 --port 5000",
+            expected: "/app/.venv/bin/flask --app main:app run --host 0.0.0.0 --port 5000",
         },
         {
             name:     "Poetry Flask project",
             path:     "../../../examples/python-poetry",
-            expected: "poetry run flask --app main.py run --host 0.0.0.0 --port 5000",
+            expected: "poetry run flask --app main:app run --host 0.0.0.0 --port 5000",
         },
     }
 
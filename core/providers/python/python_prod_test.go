@@ -0,0 +1,144 @@
+package python
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/railwayapp/railpack/core/logger"
+	testingUtils "github.com/railwayapp/railpack/core/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPython_DataApps_Prod(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		startCmd string
+		envVars  map[string]string
+	}{
+		{
+			name:     "Streamlit project",
+			path:     "../../../examples/python-streamlit",
+			startCmd: "/app/.venv/bin/streamlit run streamlit_app.py --server.address 0.0.0.0 --server.port ${PORT:-8501} --server.headless true",
+			envVars: map[string]string{
+				"STREAMLIT_SERVER_HEADLESS":            "true",
+				"STREAMLIT_BROWSER_GATHER_USAGE_STATS": "false",
+			},
+		},
+		{
+			name:     "Gradio project",
+			path:     "../../../examples/python-gradio",
+			startCmd: "GRADIO_SERVER_PORT=${PORT:-7860} /app/.venv/bin/python app.py",
+			envVars: map[string]string{
+				"GRADIO_SERVER_NAME":       "0.0.0.0",
+				"GRADIO_ANALYTICS_ENABLED": "False",
+			},
+		},
+		{
+			name:     "Jupyter project",
+			path:     "../../../examples/python-jupyter",
+			startCmd: "/app/.venv/bin/jupyter server --ip 0.0.0.0 --port ${PORT:-8888} --no-browser --allow-root",
+			envVars:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContext(t, tt.path)
+
+			provider := PythonProvider{}
+			detected, err := provider.Detect(ctx)
+			require.NoError(t, err)
+			require.True(t, detected)
+
+			require.NoError(t, provider.Initialize(ctx))
+			require.NoError(t, provider.Plan(ctx))
+
+			require.Equal(t, tt.startCmd, ctx.Deploy.StartCmd)
+			for name, value := range tt.envVars {
+				require.Equal(t, value, ctx.Deploy.Variables[name])
+			}
+		})
+	}
+}
+
+func TestPython_Voila_Prod(t *testing.T) {
+	files := map[string]string{
+		"requirements.txt": "voila\nipywidgets\n",
+		"app.ipynb":        "{}",
+	}
+
+	ctx := testingUtils.CreateGenerateContextFromFiles(t, files)
+	provider := PythonProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+
+	require.Equal(t, "/app/.venv/bin/voila app.ipynb --no-browser --Voila.ip=0.0.0.0 --port=${PORT:-8866}", provider.GetStartCommand(ctx))
+}
+
+func TestPython_Jupyter_Token(t *testing.T) {
+	jupyterTokenWarning := func(t *testing.T, token string) bool {
+		ctx := testingUtils.CreateGenerateContext(t, "../../../examples/python-jupyter")
+		if token != "" {
+			ctx.Env.SetVariable(JUPYTER_TOKEN_VAR, token)
+		}
+
+		provider := PythonProvider{}
+		require.NoError(t, provider.Initialize(ctx))
+		require.NoError(t, provider.Plan(ctx))
+
+		for _, log := range ctx.Logger.Logs {
+			if log.Level == logger.Warn && strings.Contains(log.Msg, JUPYTER_TOKEN_VAR) {
+				return true
+			}
+		}
+		return false
+	}
+
+	require.True(t, jupyterTokenWarning(t, ""))
+	require.False(t, jupyterTokenWarning(t, "secret"))
+}
+
+func TestPython_DevOnlyJupyter_Prod(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		startCmd string
+	}{
+		{
+			name: "uv dependency group",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"app\"\ndependencies = [\"requests\"]\n\n[dependency-groups]\ndev = [\"jupyter\", \"notebook>=7\"]\n",
+				"uv.lock":        "",
+				"main.py":        "print('hello')\n",
+			},
+			startCmd: "/app/.venv/bin/python main.py",
+		},
+		{
+			name: "poetry dev group",
+			files: map[string]string{
+				"pyproject.toml": "[tool.poetry]\nname = \"app\"\n\n[tool.poetry.dependencies]\npython = \"^3.12\"\n\n[tool.poetry.group.dev.dependencies]\njupyterlab = \"^4\"\n",
+				"poetry.lock":    "",
+				"main.py":        "print('hello')\n",
+			},
+			startCmd: "poetry run python main.py",
+		},
+		{
+			name: "main dependency without other entry point",
+			files: map[string]string{
+				"requirements.txt": "jupyterlab==4.2.5\n",
+				"notebook.ipynb":   "{}",
+			},
+			startCmd: "/app/.venv/bin/jupyter server --ip 0.0.0.0 --port ${PORT:-8888} --no-browser --allow-root",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContextFromFiles(t, tt.files)
+			provider := PythonProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+
+			require.Equal(t, tt.startCmd, provider.GetStartCommand(ctx))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package python
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/railwayapp/railpack/core/generate"
+)
+
+// Scripts with these names are used as the start command before any other script or main file
+var startScriptNames = []string{"start", "serve", "web"}
+
+// getProjectScripts returns the entry points from [project.scripts] and [tool.poetry.scripts]
+func getProjectScripts(pyproject *pyprojectToml) map[string]string {
+	scripts := maps.Clone(pyproject.Project.Scripts)
+	if scripts == nil {
+		scripts = map[string]string{}
+	}
+
+	for name, script := range pyproject.Tool.Poetry.Scripts {
+		switch script := script.(type) {
+		case string:
+			scripts[name] = script
+		case map[string]any:
+			// Poetry also supports tables like { reference = "app.cli:main", type = "console" }
+			if reference, ok := script["reference"].(string); ok {
+				scripts[name] = reference
+			} else if callable, ok := script["callable"].(string); ok {
+				scripts[name] = callable
+			}
+		}
+	}
+
+	return scripts
+}
+
+// findStartScript returns the script to start the app with. A script named start, serve, or web
+// is preferred, then a script named after the project, then the first script by name.
+// The returned bool is true if the script has a preferred name.
+func findStartScript(scripts map[string]string, projectName string) (string, bool) {
+	if len(scripts) == 0 {
+		return "", false
+	}
+
+	for _, name := range startScriptNames {
+		if _, ok := scripts[name]; ok {
+			return name, true
+		}
+	}
+
+	names := slices.Sorted(maps.Keys(scripts))
+	for _, name := range names {
+		if normalizePackageName(name) == normalizePackageName(projectName) {
+			return name, false
+		}
+	}
+
+	return names[0], false
+}
+
+// getScriptStartCommand returns the command that runs a script of the root pyproject.toml.
+// Poetry runs the script from the source, as the project itself is not installed. uv and
+// pdm install the project into the venv, so its scripts are in the venv bin directory.
+func (p *PythonProvider) getScriptStartCommand(ctx *generate.GenerateContext) (string, bool) {
+	var pyproject pyprojectToml
+	if err := ctx.App.ReadTOML("pyproject.toml", &pyproject); err != nil {
+		return "", false
+	}
+
+	script, preferred := findStartScript(getProjectScripts(&pyproject), pyproject.Name())
+	if script == "" {
+		return "", false
+	}
+
+	if p.hasPoetry(ctx) {
+		return fmt.Sprintf("poetry run %s", script), preferred
+	}
+
+	if p.hasUv(ctx) || p.hasPdm(ctx) {
+		return fmt.Sprintf("%s/bin/%s", p.GetVenvPath(ctx), script), preferred
+	}
+
+	return "", false
+}
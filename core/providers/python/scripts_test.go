@@ -0,0 +1,69 @@
+package python
+
+import (
+	"testing"
+
+	testingUtils "github.com/railwayapp/railpack/core/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptStartCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		startCmd string
+	}{
+		{
+			name: "uv project script",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"my-app\"\n\n[project.scripts]\nmigrate = \"my_app.db:migrate\"\nmy-app = \"my_app:main\"\n",
+				"uv.lock":        "",
+			},
+			startCmd: "/app/.venv/bin/my-app",
+		},
+		{
+			name: "preferred script over main file",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"my-app\"\n\n[project.scripts]\nserve = \"app.cli:main\"\nworker = \"app.worker:main\"\n",
+				"pdm.lock":       "",
+				"main.py":        "print('hello')\n",
+			},
+			startCmd: "/app/.venv/bin/serve",
+		},
+		{
+			name: "main file over other scripts",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"my-app\"\n\n[project.scripts]\nworker = \"app.worker:main\"\n",
+				"uv.lock":        "",
+				"main.py":        "print('hello')\n",
+			},
+			startCmd: "/app/.venv/bin/python main.py",
+		},
+		{
+			name: "poetry scripts",
+			files: map[string]string{
+				"pyproject.toml": "[tool.poetry]\nname = \"my-app\"\n\n[tool.poetry.scripts]\ncli = \"app.cli:main\"\nweb = { reference = \"app.web:run\", type = \"console\" }\n",
+				"poetry.lock":    "",
+			},
+			startCmd: "poetry run web",
+		},
+		{
+			name: "project not installed with pip",
+			files: map[string]string{
+				"pyproject.toml":   "[project]\nname = \"my-app\"\n\n[project.scripts]\nstart = \"app:main\"\n",
+				"requirements.txt": "requests\n",
+			},
+			startCmd: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContextFromFiles(t, tt.files)
+			provider := PythonProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+
+			require.Equal(t, tt.startCmd, provider.GetStartCommand(ctx))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package python
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/railwayapp/railpack/core/generate"
+)
+
+// pyprojectToml is the part of a pyproject.toml needed to find workspace members, their entry points, and main dependencies
+type pyprojectToml struct {
+	Project struct {
+		Name         string            `toml:"name"`
+		Scripts      map[string]string `toml:"scripts"`
+		Dependencies []string          `toml:"dependencies"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Name         string         `toml:"name"`
+			Scripts      map[string]any `toml:"scripts"`
+			Dependencies map[string]any `toml:"dependencies"`
+		} `toml:"poetry"`
+		Uv struct {
+			Workspace *struct {
+				Members []string `toml:"members"`
+				Exclude []string `toml:"exclude"`
+			} `toml:"workspace"`
+		} `toml:"uv"`
+	} `toml:"tool"`
+}
+
+func (p *pyprojectToml) Name() string {
+	if p.Project.Name != "" {
+		return p.Project.Name
+	}
+	return p.Tool.Poetry.Name
+}
+
+// pythonPackage is a member of a uv workspace or a Poetry project in a subdirectory
+type pythonPackage struct {
+	Path      string
+	Pyproject *pyprojectToml
+}
+
+func (p *pythonPackage) Name() string {
+	return p.Pyproject.Name()
+}
+
+// findPythonPackages returns the members of a uv workspace. Without a uv workspace
+// (e.g. Poetry path dependencies), every subdirectory with a pyproject.toml is a candidate.
+func findPythonPackages(ctx *generate.GenerateContext) ([]*pythonPackage, error) {
+	dirs := []string{}
+
+	var root pyprojectToml
+	if err := ctx.App.ReadTOML("pyproject.toml", &root); err == nil && root.Tool.Uv.Workspace != nil {
+		for _, pattern := range root.Tool.Uv.Workspace.Members {
+			matches, err := ctx.App.FindDirectories(pattern)
+			if err != nil {
+				return nil, err
+			}
+			dirs = append(dirs, matches...)
+		}
+
+		for _, pattern := range root.Tool.Uv.Workspace.Exclude {
+			dirs = slices.DeleteFunc(dirs, func(dir string) bool {
+				matched, _ := path.Match(pattern, dir)
+				return matched
+			})
+		}
+	} else {
+		files, err := ctx.App.FindFiles("**/pyproject.toml")
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if dir := path.Dir(file); dir != "." && !isIgnoredPackageDir(dir) {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+
+	sort.Strings(dirs)
+
+	packages := []*pythonPackage{}
+	for _, dir := range slices.Compact(dirs) {
+		var pyproject pyprojectToml
+		if err := ctx.App.ReadTOML(path.Join(dir, "pyproject.toml"), &pyproject); err != nil {
+			continue
+		}
+		packages = append(packages, &pythonPackage{Path: dir, Pyproject: &pyproject})
+	}
+
+	return packages, nil
+}
+
+// findPythonPackage finds a workspace member by its path or project name
+func findPythonPackage(packages []*pythonPackage, pathOrName string) *pythonPackage {
+	target := strings.TrimSuffix(strings.TrimPrefix(path.Clean(pathOrName), "./"), "/")
+
+	for _, pkg := range packages {
+		if pkg.Path == target || normalizePackageName(pkg.Name()) == normalizePackageName(target) {
+			return pkg
+		}
+	}
+
+	return nil
+}
+
+func (p *PythonProvider) getPackageName(ctx *generate.GenerateContext) string {
+	if ctx.Config == nil || ctx.Config.Python == nil {
+		return ""
+	}
+	return ctx.Config.Python.Package
+}
+
+func (p *PythonProvider) pythonPackagePaths() []string {
+	paths := []string{}
+	for _, pkg := range p.packages {
+		paths = append(paths, pkg.Path)
+	}
+	return paths
+}
+
+// getPackageStartCommand runs the entry point of the targeted workspace member. Scripts are installed
+// into the venv when the member is synced. Otherwise the main module of the member is run.
+func (p *PythonProvider) getPackageStartCommand(ctx *generate.GenerateContext) string {
+	venvPath := p.GetVenvPath(ctx)
+
+	if script, _ := findStartScript(getProjectScripts(p.pythonPackage.Pyproject), p.pythonPackage.Name()); script != "" {
+		return fmt.Sprintf("%s/bin/%s", venvPath, script)
+	}
+
+	for _, file := range []string{"main.py", "app.py", "server.py", "run.py"} {
+		if file = path.Join(p.pythonPackage.Path, file); ctx.App.HasFile(file) {
+			return fmt.Sprintf("%s/bin/python %s", venvPath, file)
+		}
+	}
+
+	for _, dir := range []string{"src/*", "*"} {
+		matches, err := ctx.App.FindFiles(path.Join(p.pythonPackage.Path, dir, "__main__.py"))
+		if err != nil || len(matches) == 0 {
+			continue
+		}
+
+		module := path.Base(path.Dir(matches[0]))
+		return fmt.Sprintf("%s/bin/python -m %s", venvPath, module)
+	}
+
+	return ""
+}
+
+func isIgnoredPackageDir(dir string) bool {
+	for _, name := range strings.Split(dir, "/") {
+		if slices.Contains(ignoredAppDirs, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePackageName normalizes a Python package name as described in PEP 503
+func normalizePackageName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name))
+}
@@ -0,0 +1,79 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/railwayapp/railpack/core/config"
+	"github.com/railwayapp/railpack/core/generate"
+	"github.com/railwayapp/railpack/core/plan"
+	testingUtils "github.com/railwayapp/railpack/core/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPythonUvWorkspacePackage(t *testing.T) {
+	for _, packageName := range []string{"packages/api", "./packages/api/", "api"} {
+		t.Run(packageName, func(t *testing.T) {
+			ctx := testingUtils.CreateGenerateContext(t, "../../../examples/python-uv-workspace-package")
+			ctx.Config.Python = &config.PythonConfig{Package: packageName}
+
+			provider := PythonProvider{}
+			require.NoError(t, provider.Initialize(ctx))
+			require.NoError(t, provider.Plan(ctx))
+
+			require.Equal(t, "packages/api", ctx.Metadata.Get("pythonPackage"))
+			require.Equal(t, "/app/.venv/bin/api", ctx.Deploy.StartCmd)
+
+			buildPlan, _, err := ctx.Generate()
+			require.NoError(t, err)
+
+			commands := map[string][]string{}
+			for _, step := range buildPlan.Steps {
+				for _, cmd := range step.Commands {
+					if execCmd, ok := cmd.(plan.ExecCommand); ok {
+						commands[step.Name] = append(commands[step.Name], execCmd.Cmd)
+					}
+				}
+			}
+
+			require.Contains(t, commands["install"], "/app/.venv/bin/uv sync --locked --no-dev --no-install-project --package api")
+			require.Equal(t, []string{"/app/.venv/bin/uv sync --locked --no-dev --no-editable --package api"}, commands["build"])
+		})
+	}
+}
+
+func TestPythonWorkspacePackageNotFound(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/python-uv-workspace-package")
+	ctx.Config.Python = &config.PythonConfig{Package: "packages/missing"}
+
+	provider := PythonProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+
+	err := provider.Plan(ctx)
+	require.EqualError(t, err, "python package `packages/missing` not found. Available packages: packages/api, packages/shared, packages/worker")
+}
+
+func TestPythonPoetryPackage(t *testing.T) {
+	files := map[string]string{
+		"pyproject.toml":               "[tool.poetry]\nname = \"monorepo\"\n\n[tool.poetry.dependencies]\npython = \"^3.12\"\n",
+		"poetry.lock":                  "",
+		"services/web/pyproject.toml":  "[tool.poetry]\nname = \"web_service\"\n\n[tool.poetry.dependencies]\npython = \"^3.12\"\nflask = \"^3.0\"\n",
+		"services/web/poetry.lock":     "",
+		"services/web/web/__main__.py": "print('web')\n",
+	}
+
+	ctx := testingUtils.CreateGenerateContextFromFiles(t, files)
+	ctx.Config.Python = &config.PythonConfig{Package: "web-service"}
+
+	provider := PythonProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	require.Equal(t, "/app/.venv/bin/python -m web", ctx.Deploy.StartCmd)
+	require.True(t, provider.isFlask(ctx))
+
+	install := (*ctx.GetStepByName("install")).(*generate.CommandStepBuilder)
+	require.Contains(t, install.Commands, plan.NewExecCommand("/app/.venv/bin/poetry install --no-interaction --no-ansi --only main --no-root --directory services/web"))
+
+	build := (*ctx.GetStepByName("build")).(*generate.CommandStepBuilder)
+	require.Equal(t, []plan.Command{plan.NewExecCommand("/app/.venv/bin/poetry install --no-interaction --no-ansi --only main --directory services/web")}, build.Commands)
+}
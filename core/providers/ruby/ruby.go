@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/railwayapp/railpack/core/config"
 	"github.com/railwayapp/railpack/core/generate"
 	"github.com/railwayapp/railpack/core/plan"
 	"github.com/railwayapp/railpack/core/providers/node"
@@ -86,6 +87,9 @@ func (p *RubyProvider) Plan(ctx *generate.GenerateContext) error {
 	ctx.Deploy.StartCmd = p.GetStartCommand(ctx)
 	maps.Copy(ctx.Deploy.Variables, p.GetRubyEnvVars(ctx))
 	p.AddRuntimeDeps(ctx)
+	ctx.AddSystemPackages("ruby", rubySystemPackages, func(dep string) bool {
+		return p.usesDep(ctx, dep)
+	})
 
 	ctx.Deploy.AddInputs([]plan.Layer{
 		plan.NewStepLayer(miseStep.Name(), plan.Filter{
@@ -194,29 +198,7 @@ func (p *RubyProvider) Build(ctx *generate.GenerateContext, build *generate.Comm
 }
 
 func (p *RubyProvider) AddRuntimeDeps(ctx *generate.GenerateContext) {
-	packages := []string{"libyaml-dev", "libjemalloc-dev"}
-
-	if p.usesPostgres(ctx) {
-		packages = append(packages, "libpq-dev")
-	}
-
-	if p.usesMysql(ctx) {
-		packages = append(packages, "default-libmysqlclient-dev")
-	}
-
-	if p.usesDep(ctx, "magick") {
-		packages = append(packages, "libmagickwand-dev")
-	}
-
-	if p.usesDep(ctx, "vips") {
-		packages = append(packages, "libvips-dev")
-	}
-
-	if p.usesDep(ctx, "charlock_holmes") {
-		packages = append(packages, "libicu-dev", "libxml2-dev", "libxslt-dev")
-	}
-
-	ctx.Deploy.AddAptPackages(packages)
+	ctx.Deploy.AddAptPackages([]string{"libyaml-dev", "libjemalloc-dev"})
 }
 
 func (p *RubyProvider) GetBuilderDeps(ctx *generate.GenerateContext) *generate.MiseStepBuilder {
@@ -393,3 +375,14 @@ func parseLocalPathsFromGemfile(ctx *generate.GenerateContext) []string {
 
 	return paths
 }
+
+// Mapping of gems to the apt packages needed to build and run them. Gems with native
+// extensions that are precompiled for Linux (e.g. nokogiri) do not need any packages.
+
+var rubySystemPackages = map[string]config.SystemPackages{
+	"pg":              {Runtime: []string{"libpq-dev"}},
+	"mysql":           {Runtime: []string{"default-libmysqlclient-dev"}},
+	"magick":          {Runtime: []string{"libmagickwand-dev"}},
+	"vips":            {Runtime: []string{"libvips-dev"}},
+	"charlock_holmes": {Runtime: []string{"libicu-dev", "libxml2-dev", "libxslt-dev"}},
+}
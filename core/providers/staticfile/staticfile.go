@@ -82,7 +82,13 @@ func (p *StaticfileProvider) Plan(ctx *generate.GenerateContext) error {
 		build.AddInput(plan.NewStepLayer(miseStep.Name()))
 		build.AddInput(plan.NewLocalLayer())
 
-		err := p.addCaddyfileToStep(ctx, build)
+		staticServer, err := ctx.NewStaticServer([]string{p.RootDir})
+		if err != nil {
+			return err
+		}
+		staticServer.AddPrecompression(miseStep, build, p.RootDir, "")
+
+		err = p.addCaddyfileToStep(ctx, build, staticServer)
 		if err != nil {
 			return err
 		}
@@ -115,11 +121,12 @@ func (p *StaticfileProvider) StartCommandHelp() string {
 		"In development mode, your files will be served using Node.js lite-server"
 }
 
-func (p *StaticfileProvider) addCaddyfileToStep(ctx *generate.GenerateContext, setup *generate.CommandStepBuilder) error {
+func (p *StaticfileProvider) addCaddyfileToStep(ctx *generate.GenerateContext, setup *generate.CommandStepBuilder, staticServer *generate.StaticServer) error {
 	ctx.Logger.LogInfo("Using root dir: %s", p.RootDir)
 
 	data := map[string]interface{}{
 		"STATIC_FILE_ROOT": p.RootDir,
+		"STATIC":           staticServer,
 	}
 
 	caddyfileTemplate, err := ctx.TemplateFiles([]string{"Caddyfile.template", "Caddyfile"}, caddyfileTemplate, data)
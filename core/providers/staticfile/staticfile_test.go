@@ -1,8 +1,11 @@
 package staticfile
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/railwayapp/railpack/core/config"
+	"github.com/railwayapp/railpack/core/plan"
 	testingUtils "github.com/railwayapp/railpack/core/testing"
 	"github.com/stretchr/testify/require"
 )
@@ -167,3 +170,40 @@ func TestProductionMode_UsesCaddy(t *testing.T) {
 	require.Contains(t, ctx.Deploy.StartCmd, "--config Caddyfile")
 	require.Empty(t, ctx.Deploy.RequiredPort) // Production mode should NOT have requiredPort
 }
+
+func TestProductionMode_StaticServer(t *testing.T) {
+	ctx := testingUtils.CreateGenerateContext(t, "../../../examples/staticfile-redirects")
+	ctx.Config.Static = &config.StaticConfig{
+		CacheControl: map[string]string{"/assets/*": "public, max-age=31536000, immutable"},
+		Precompress:  true,
+	}
+
+	provider := StaticfileProvider{}
+	require.NoError(t, provider.Initialize(ctx))
+	require.NoError(t, provider.Plan(ctx))
+
+	buildPlan, _, err := ctx.Generate()
+	require.NoError(t, err)
+
+	var build plan.Step
+	for _, step := range buildPlan.Steps {
+		if step.Name == "build" {
+			build = step
+		}
+	}
+
+	caddyfile := build.Assets["Caddyfile"]
+	require.Contains(t, caddyfile, `header /assets/* {
+		Cache-Control "public, max-age=31536000, immutable"
+	}`)
+	require.Contains(t, caddyfile, `header /* {
+		X-Frame-Options "DENY"
+	}`)
+	require.Contains(t, caddyfile, `redir @redirect0 /guides/{re.redirect0.1} 301`)
+	require.Contains(t, caddyfile, `handle_path /api/* {
+		reverse_proxy https://api.example.com {`)
+	require.Contains(t, caddyfile, "precompressed br gzip")
+
+	precompress := build.Commands[0].(plan.ExecCommand)
+	require.True(t, strings.Contains(precompress.Cmd, "gzip -9 -k -f") && strings.Contains(precompress.Cmd, "brotli -q 11 -k -f"))
+}
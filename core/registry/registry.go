@@ -0,0 +1,199 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	dockerHubRegistry = "registry-1.docker.io"
+	defaultTag        = "latest"
+)
+
+var (
+	manifestMediaTypes = []string{
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}
+
+	authParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+	httpClient = &http.Client{Timeout: 30 * time.Second}
+)
+
+// Reference is a parsed image reference (e.g. ghcr.io/railwayapp/railpack-builder:latest)
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses an image reference, applying the same defaults as Docker
+// (docker.io registry, library/ namespace, and the latest tag)
+func ParseReference(ref string) Reference {
+	parsed := Reference{}
+
+	name := ref
+	if idx := strings.Index(name, "@"); idx != -1 {
+		parsed.Digest = name[idx+1:]
+		name = name[:idx]
+	}
+
+	// A colon after the last slash separates the tag, anything before is a registry port
+	if idx := strings.LastIndex(name, ":"); idx != -1 && !strings.Contains(name[idx:], "/") {
+		parsed.Tag = name[idx+1:]
+		name = name[:idx]
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		parsed.Registry = parts[0]
+		parsed.Repository = parts[1]
+	} else {
+		parsed.Registry = dockerHubRegistry
+		parsed.Repository = name
+		if !strings.Contains(name, "/") {
+			parsed.Repository = "library/" + name
+		}
+	}
+
+	if parsed.Registry == "docker.io" || parsed.Registry == "index.docker.io" {
+		parsed.Registry = dockerHubRegistry
+	}
+
+	if parsed.Tag == "" && parsed.Digest == "" {
+		parsed.Tag = defaultTag
+	}
+
+	return parsed
+}
+
+// IsPinned returns true if the image reference includes a digest
+func IsPinned(ref string) bool {
+	return ParseReference(ref).Digest != ""
+}
+
+// WithDigest returns the image reference pinned to the given digest.
+// The tag is kept so the reference stays readable.
+func WithDigest(ref, digest string) string {
+	if idx := strings.Index(ref, "@"); idx != -1 {
+		ref = ref[:idx]
+	}
+	return ref + "@" + digest
+}
+
+// ResolveDigest returns the manifest digest (e.g. sha256:...) that the image reference currently points to
+func ResolveDigest(ref string) (string, error) {
+	parsed := ParseReference(ref)
+	if parsed.Digest != "" {
+		return parsed.Digest, nil
+	}
+
+	manifestUrl := fmt.Sprintf("https://%s/v2/%s/manifests/%s", parsed.Registry, parsed.Repository, parsed.Tag)
+
+	resp, err := headManifest(manifestUrl, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve digest for %s: %w", ref, err)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := fetchToken(resp.Header.Get("WWW-Authenticate"), parsed.Repository)
+		if err != nil {
+			return "", fmt.Errorf("failed to authenticate with %s: %w", parsed.Registry, err)
+		}
+
+		resp, err = headManifest(manifestUrl, token)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve digest for %s: %w", ref, err)
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to resolve digest for %s: registry returned %s", ref, resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("failed to resolve digest for %s: registry did not return a digest", ref)
+	}
+
+	return digest, nil
+}
+
+func headManifest(manifestUrl, token string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodHead, manifestUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+// fetchToken requests an anonymous pull token from the realm advertised by the registry
+func fetchToken(challenge, repository string) (string, error) {
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return "", fmt.Errorf("unsupported auth challenge %q", challenge)
+	}
+
+	params := map[string]string{}
+	for _, match := range authParamRegex.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("auth challenge is missing a realm")
+	}
+
+	query := url.Values{}
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", repository)
+	}
+	query.Set("scope", scope)
+
+	resp, err := httpClient.Get(realm + "?" + query.Encode())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
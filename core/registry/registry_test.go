@@ -0,0 +1,52 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected Reference
+	}{
+		{
+			ref:      "alpine:latest",
+			expected: Reference{Registry: "registry-1.docker.io", Repository: "library/alpine", Tag: "latest"},
+		},
+		{
+			ref:      "alpine",
+			expected: Reference{Registry: "registry-1.docker.io", Repository: "library/alpine", Tag: "latest"},
+		},
+		{
+			ref:      "dunglas/frankenphp:php8.4-bookworm",
+			expected: Reference{Registry: "registry-1.docker.io", Repository: "dunglas/frankenphp", Tag: "php8.4-bookworm"},
+		},
+		{
+			ref:      "ghcr.io/railwayapp/railpack-builder:latest",
+			expected: Reference{Registry: "ghcr.io", Repository: "railwayapp/railpack-builder", Tag: "latest"},
+		},
+		{
+			ref:      "localhost:5000/app:v1",
+			expected: Reference{Registry: "localhost:5000", Repository: "app", Tag: "v1"},
+		},
+		{
+			ref:      "ghcr.io/railwayapp/railpack-runtime:latest@sha256:abc",
+			expected: Reference{Registry: "ghcr.io", Repository: "railwayapp/railpack-runtime", Tag: "latest", Digest: "sha256:abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			require.Equal(t, tt.expected, ParseReference(tt.ref))
+		})
+	}
+}
+
+func TestWithDigest(t *testing.T) {
+	require.Equal(t, "alpine:latest@sha256:abc", WithDigest("alpine:latest", "sha256:abc"))
+	require.Equal(t, "alpine:latest@sha256:def", WithDigest("alpine:latest@sha256:abc", "sha256:def"))
+	require.True(t, IsPinned("alpine:latest@sha256:abc"))
+	require.False(t, IsPinned("alpine:latest"))
+}
@@ -16,6 +16,7 @@ type Resolver struct {
 	mise             *mise.Mise
 	packages         map[string]*RequestedPackage
 	previousVersions map[string]string
+	lockedVersions   map[string]*ResolvedPackage
 }
 
 type RequestedPackage struct {
@@ -36,6 +37,9 @@ type ResolvedPackage struct {
 	RequestedVersion *string `json:"requestedVersion,omitempty"`
 	ResolvedVersion  *string `json:"resolvedVersion,omitempty"`
 	Source           string  `json:"source"`
+
+	// If true, the resolved version was read from the lock file instead of being resolved with mise
+	Locked bool `json:"locked,omitempty"`
 }
 
 type PackageRef struct {
@@ -66,6 +70,7 @@ func NewResolver(miseDir string) (*Resolver, error) {
 		mise:             mise,
 		packages:         make(map[string]*RequestedPackage),
 		previousVersions: make(map[string]string),
+		lockedVersions:   make(map[string]*ResolvedPackage),
 	}, nil
 }
 
@@ -73,6 +78,22 @@ func (r *Resolver) ResolvePackages() (map[string]*ResolvedPackage, error) {
 	resolvedPackages := make(map[string]*ResolvedPackage)
 
 	for name, pkg := range r.packages {
+		// Use the locked version as long as the requested version has not changed since the package was locked
+		if locked := r.lockedVersions[name]; locked != nil && locked.ResolvedVersion != nil &&
+			locked.RequestedVersion != nil && *locked.RequestedVersion == pkg.Version {
+			log.Debugf("Using locked version %s %s", name, *locked.ResolvedVersion)
+
+			lockedVersion := *locked.ResolvedVersion
+			resolvedPackages[name] = &ResolvedPackage{
+				Name:             name,
+				RequestedVersion: &pkg.Version,
+				ResolvedVersion:  &lockedVersion,
+				Source:           pkg.Source,
+				Locked:           true,
+			}
+			continue
+		}
+
 		fuzzyVersion := resolveToFuzzyVersion(pkg.Version)
 
 		var latestVersion string
@@ -144,6 +165,14 @@ func (r *Resolver) SetPreviousVersion(name, version string) {
 	r.previousVersions[name] = version
 }
 
+// SetLockedVersion pins the resolved version of a package, as long as the requested version matches the locked one
+func (r *Resolver) SetLockedVersion(pkg *ResolvedPackage) {
+	if pkg == nil {
+		return
+	}
+	r.lockedVersions[pkg.Name] = pkg
+}
+
 func (r *Resolver) SetVersionAvailable(ref PackageRef, isVersionAvailable func(version string) bool) {
 	r.packages[ref.Name].IsVersionAvailable = isVersionAvailable
 }
@@ -116,3 +116,34 @@ func TestResolvingPackagesNotAvailable(t *testing.T) {
 	_, err = resolver.ResolvePackages()
 	require.Error(t, err)
 }
+
+func TestPackageResolverWithLockedVersions(t *testing.T) {
+	resolver, err := NewResolver(mise.TestInstallDir)
+	require.NoError(t, err)
+
+	requested := "22"
+	locked := "22.1.0"
+	resolver.SetLockedVersion(&ResolvedPackage{Name: "node", RequestedVersion: &requested, ResolvedVersion: &locked})
+
+	staleRequested := "3.11"
+	staleLocked := "3.11.4"
+	resolver.SetLockedVersion(&ResolvedPackage{Name: "python", RequestedVersion: &staleRequested, ResolvedVersion: &staleLocked})
+
+	resolver.Default("node", "22")
+	resolver.Default("python", "3.13")
+
+	resolvedPackages, err := resolver.ResolvePackages()
+	require.NoError(t, err)
+
+	// The locked version is used when the requested version is unchanged
+	nodeResolved := resolvedPackages["node"]
+	require.NotNil(t, nodeResolved)
+	assert.Equal(t, "22.1.0", *nodeResolved.ResolvedVersion)
+	assert.True(t, nodeResolved.Locked)
+
+	// The locked version is ignored when the requested version changed
+	pythonResolved := resolvedPackages["python"]
+	require.NotNil(t, pythonResolved)
+	assert.False(t, pythonResolved.Locked)
+	assert.Contains(t, *pythonResolved.ResolvedVersion, "3.13")
+}
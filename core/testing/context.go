@@ -1,6 +1,8 @@
 package testing
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/railwayapp/railpack/core/app"
@@ -29,3 +31,29 @@ func CreateGenerateContext(t *testing.T, path string) *generate.GenerateContext
 
 	return ctx
 }
+
+// CreateGenerateContextFromFiles creates a new GenerateContext for an app with the given files
+func CreateGenerateContextFromFiles(t *testing.T, files map[string]string) *generate.GenerateContext {
+	t.Helper()
+
+	return CreateGenerateContext(t, CreateAppDir(t, files))
+}
+
+// CreateAppDir writes the files to a temporary app directory and returns its path.
+// Files can be in subdirectories, which are created as needed.
+func CreateAppDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("error creating directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatalf("error writing file: %v", err)
+		}
+	}
+
+	return dir
+}
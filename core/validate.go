@@ -30,6 +30,10 @@ func ValidatePlan(plan *plan.BuildPlan, app *app.App, logger *logger.Logger, opt
 		}
 	}
 
+	if !validateStepGraph(plan, logger) {
+		return false
+	}
+
 	return validateDeployLayers(plan, logger)
 }
 
@@ -95,6 +99,16 @@ func validateInputs(inputs []plan.Layer, stepName string, logger *logger.Logger)
 	return true
 }
 
+// validateStepGraph checks that step inputs reference existing steps and do not form a cycle
+func validateStepGraph(plan *plan.BuildPlan, logger *logger.Logger) bool {
+	if err := plan.ValidateStepGraph(); err != nil {
+		logger.LogError("%s", err.Error())
+		return false
+	}
+
+	return true
+}
+
 func validateDeployLayers(plan *plan.BuildPlan, logger *logger.Logger) bool {
 	if plan.Deploy.Base.Image == "" && plan.Deploy.Base.Step == "" {
 		logger.LogError("deploy.base is required")
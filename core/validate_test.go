@@ -112,3 +112,26 @@ func TestValidateInputs(t *testing.T) {
 		require.False(t, validateInputs(inputs, "test", logger))
 	})
 }
+
+func TestValidateStepGraph(t *testing.T) {
+	logger := logger.NewLogger()
+
+	t.Run("valid graph", func(t *testing.T) {
+		buildPlan := plan.NewBuildPlan()
+		buildPlan.Steps = []plan.Step{
+			{Name: "install", Inputs: []plan.Layer{plan.NewImageLayer("node:18")}},
+			{Name: "build", Inputs: []plan.Layer{plan.NewStepLayer("install")}},
+		}
+		require.True(t, validateStepGraph(buildPlan, logger))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		buildPlan := plan.NewBuildPlan()
+		buildPlan.Steps = []plan.Step{
+			{Name: "install", Inputs: []plan.Layer{plan.NewStepLayer("build")}},
+			{Name: "build", Inputs: []plan.Layer{plan.NewStepLayer("install")}},
+		}
+		require.False(t, validateStepGraph(buildPlan, logger))
+		require.Contains(t, logger.Logs[len(logger.Logs)-1].Msg, "install -> build -> install")
+	})
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"multiple-bins/internal/greeting"
+)
+
+func main() {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, greeting.Hello("api"))
+	})
+
+	fmt.Println(greeting.Hello("Go api"))
+	http.ListenAndServe(":"+port, nil)
+}
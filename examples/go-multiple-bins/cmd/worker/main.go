@@ -0,0 +1,11 @@
+package main
+
+import (
+	"fmt"
+
+	"multiple-bins/internal/greeting"
+)
+
+func main() {
+	fmt.Println(greeting.Hello("Go worker"))
+}
@@ -0,0 +1,3 @@
+module multiple-bins
+
+go 1.23
@@ -0,0 +1,8 @@
+const server = Bun.serve({
+  port: process.env.PORT ?? 3000,
+  fetch() {
+    return new Response("hello from Bun.serve");
+  },
+});
+
+console.log(`Listening on ${server.url}`);
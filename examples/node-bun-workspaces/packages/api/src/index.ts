@@ -0,0 +1,10 @@
+import { greeting } from "shared";
+
+Bun.serve({
+  port: process.env.PORT ?? 3000,
+  fetch() {
+    return new Response(greeting);
+  },
+});
+
+console.log("Listening");
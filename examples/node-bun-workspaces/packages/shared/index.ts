@@ -0,0 +1 @@
+export const greeting = "hello from a bun workspace";
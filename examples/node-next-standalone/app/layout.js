@@ -0,0 +1,11 @@
+export const metadata = {
+  title: "Next standalone",
+};
+
+export default function RootLayout({ children }) {
+  return (
+    <html lang="en">
+      <body>{children}</body>
+    </html>
+  );
+}
@@ -0,0 +1,3 @@
+const fs = require("fs");
+
+fs.writeFileSync("dist.txt", "built");
@@ -0,0 +1,4 @@
+const fs = require("fs");
+const { greet } = require("@acme/shared");
+
+console.log(greet("api"), fs.readFileSync("dist.txt", "utf8"));
@@ -0,0 +1,8 @@
+import sys
+
+import numpy as np
+
+# The interpreter comes from the conda environment, not from mise
+assert sys.prefix == "/opt/conda", f"Expected sys.prefix to be /opt/conda but got {sys.prefix}"
+
+print(f"Hello from conda! {np.arange(3).sum()}")
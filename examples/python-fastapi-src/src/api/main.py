@@ -0,0 +1,11 @@
+from fastapi import FastAPI
+
+from api.routes.health import router as health_router
+
+app = FastAPI()
+app.include_router(health_router)
+
+
+@app.get("/")
+def read_root():
+    return {"message": "Hello from FastAPI in a src layout!"}
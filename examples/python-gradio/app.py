@@ -0,0 +1,12 @@
+import gradio as gr
+
+
+def greet(name: str) -> str:
+    return f"Hello, {name}!"
+
+
+demo = gr.Interface(fn=greet, inputs="text", outputs="text")
+
+if __name__ == "__main__":
+    # The host and port are read from GRADIO_SERVER_NAME and GRADIO_SERVER_PORT
+    demo.launch()
@@ -0,0 +1,7 @@
+import sys
+
+import numpy as np
+
+assert sys.prefix == "/app/.pixi/envs/default", f"Expected sys.prefix to be /app/.pixi/envs/default but got {sys.prefix}"
+
+print(f"Hello from pixi! {np.arange(3).sum()}")
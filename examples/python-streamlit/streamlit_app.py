@@ -0,0 +1,5 @@
+import streamlit as st
+
+st.title("Hello from Streamlit!")
+name = st.text_input("Name", "Railpack")
+st.write(f"Hello, {name}!")
@@ -0,0 +1,6 @@
+import shared
+
+
+def main() -> None:
+    # test.json doesn't like newlines
+    print(f"Hello from api and {shared.name()}!", end="")